@@ -0,0 +1,187 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+// Package address implements TurtleCoin public addresses. An address is
+// the base58 encoding of
+//
+//	prefix (varint) || public spend key (32) || public view key (32) || checksum (4)
+//
+// where the checksum is the start of the keccak hash of the rest.
+package address
+
+import (
+	"errors"
+
+	"github.com/turtlecoin/go-turtlecoin/base58"
+	"github.com/turtlecoin/go-turtlecoin/config"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keccak"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/varint"
+)
+
+// TurtleCoinPrefix is the prefix of TurtleCoin addresses, which makes
+// them start with TRTL
+const TurtleCoinPrefix = config.TurtleCoinAddressPrefix
+
+// checksumSize is the number of bytes of the keccak hash at the end of an
+// address
+const checksumSize = 4
+
+// ErrInvalidLength is returned when an address decodes to the wrong
+// number of bytes
+var ErrInvalidLength = errors.New("address has the wrong length")
+
+// ErrInvalidPrefix is returned when the prefix of an address is not a
+// valid varint
+var ErrInvalidPrefix = errors.New("address prefix is not a valid varint")
+
+// ErrWrongPrefix is returned when an address is for a different coin or
+// network
+var ErrWrongPrefix = errors.New("address prefix is not the expected prefix")
+
+// ErrInvalidChecksum is returned when the checksum of an address doesn't
+// match, usually because it has been mistyped
+var ErrInvalidChecksum = errors.New("address checksum does not match")
+
+// ErrInvalidSpendKey is returned when the public spend key of an address
+// is not a valid point
+var ErrInvalidSpendKey = errors.New("address public spend key is not a valid point")
+
+// ErrInvalidViewKey is returned when the public view key of an address is
+// not a valid point
+var ErrInvalidViewKey = errors.New("address public view key is not a valid point")
+
+// Address is a public address, the keys payments to it are sent to
+type Address struct {
+	Prefix   uint64
+	SpendKey keys.PublicKey
+	ViewKey  keys.PublicKey
+}
+
+// New returns the TurtleCoin address of the given public keys
+func New(spendKey, viewKey keys.PublicKey) Address {
+	return NewForNetwork(config.Mainnet, spendKey, viewKey)
+}
+
+// NewForNetwork returns the address of the given public keys on network
+func NewForNetwork(network *config.NetworkParameters, spendKey, viewKey keys.PublicKey) Address {
+	return Address{
+		Prefix:   network.AddressPrefix,
+		SpendKey: spendKey,
+		ViewKey:  viewKey,
+	}
+}
+
+// checksum returns the checksum of the start of an address
+func checksum(data []byte) []byte {
+	return keccak.Keccak(data, 32)[:checksumSize]
+}
+
+// encode returns the base58 encoding of prefix || data || checksum
+func encode(prefix uint64, data []byte) string {
+	buf := varint.Append(nil, prefix)
+
+	buf = append(buf, data...)
+	buf = append(buf, checksum(buf)...)
+
+	return base58.Encode(buf)
+}
+
+// decode checks the encoding, prefix and checksum of s and returns the
+// data between the prefix and the checksum, which must be size bytes
+func decode(s string, prefix uint64, size int) ([]byte, error) {
+	data, err := base58.Decode(s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	n, start, err := varint.Decode(data)
+
+	if err != nil {
+		return nil, ErrInvalidPrefix
+	}
+
+	if len(data) != start+size+checksumSize {
+		return nil, ErrInvalidLength
+	}
+
+	end := len(data) - checksumSize
+
+	if string(checksum(data[:end])) != string(data[end:]) {
+		return nil, ErrInvalidChecksum
+	}
+
+	if n != prefix {
+		return nil, ErrWrongPrefix
+	}
+
+	return data[start:end], nil
+}
+
+// fromKeys returns the address with the spend and view keys in data
+func fromKeys(prefix uint64, data []byte) (Address, error) {
+	a := Address{Prefix: prefix}
+
+	copy(a.SpendKey[:], data[:32])
+	copy(a.ViewKey[:], data[32:64])
+
+	if !keys.CheckKey(a.SpendKey) {
+		return Address{}, ErrInvalidSpendKey
+	}
+
+	if !keys.CheckKey(a.ViewKey) {
+		return Address{}, ErrInvalidViewKey
+	}
+
+	return a, nil
+}
+
+// String returns the address in base58
+func (a Address) String() string {
+	return encode(a.Prefix, append(a.SpendKey[:], a.ViewKey[:]...))
+}
+
+// Parse decodes and validates a TurtleCoin address
+func Parse(s string) (Address, error) {
+	return ParseForNetwork(s, config.Mainnet)
+}
+
+// ParseForNetwork decodes and validates an address on network
+func ParseForNetwork(s string, network *config.NetworkParameters) (Address, error) {
+	return ParseWithPrefix(s, network.AddressPrefix)
+}
+
+// ParseWithPrefix decodes and validates an address with the given prefix.
+// The error says why an address isn't valid. A base58 error means it
+// contains characters which can't be in an address, or is truncated.
+func ParseWithPrefix(s string, prefix uint64) (Address, error) {
+	data, err := decode(s, prefix, 64)
+
+	if err != nil {
+		return Address{}, err
+	}
+
+	return fromKeys(prefix, data)
+}
+
+// Validate returns nil if s is a valid TurtleCoin address, otherwise the
+// reason it is not
+func Validate(s string) error {
+	return ValidateForNetwork(s, config.Mainnet)
+}
+
+// ValidateForNetwork returns nil if s is a valid address on network,
+// otherwise the reason it is not
+func ValidateForNetwork(s string, network *config.NetworkParameters) error {
+	_, err := ParseForNetwork(s, network)
+
+	return err
+}
@@ -0,0 +1,190 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package address
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/base58"
+	"github.com/turtlecoin/go-turtlecoin/config"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/varint"
+)
+
+// addressTests are Monero mainnet, stagenet and testnet addresses and
+// subaddresses, as used by the tests of chekist32/go-monero. Monero
+// addresses have the same layout as TurtleCoin addresses, with single
+// byte prefixes.
+var addressTests = []struct {
+	address string
+	prefix  uint64
+	spend   string
+	view    string
+}{
+	{
+		"48ukkZtBSBRL8iva7k3p2sBVMLWTfNwsTbW1aVh5M84g21muDCssvCHTpoZCaSc6rq8M9QLZ3sQMrMn1bq2RD2anGnyHhtq", 0x12,
+		"c04ac8adc844e07263bf9a4dd337883eb55db89743c9aece4357381ae6c0b106",
+		"0ef3c9e1146ed2a05f0eb4b25e41662bed41fa246251257c363a8ba95750cb8b",
+	},
+	{
+		"84nvgV2eTnG1vAKbg87MnbfjWrSY3eH3s2eykmggk549C8zdNk4PPD7iv7BPfPsnoH9NjXaRhjC19FY6PBmXZUtoG5SEiY7", 0x2a,
+		"3dba53246e6981057ad2a9eff6d164e791cdef4578caee09e4b6ea03af774e42",
+		"96b37bede10496fa98e0a7c58c49403211b225643621e456e7d2d4d8c13b6885",
+	},
+	{
+		"53zEYzu2hi3e97tdMTqTvSRAfFYXwxA7LBJEHLWvFnm699WgcsE8CJujENwNAQotKyY2u94vpbGEZTiwahuMcMfX3x6NFwY", 0x18,
+		"38e9908d33d034de0ba1281aa7afe3907b795cea14852b3d8fe276e8931cb130",
+		"b4cdbf52851002fc7b098b99536df8b9885aa6cb8db24e9fc46103674dc9421a",
+	},
+	{
+		"74xhb5sXRsnDZv8RKFEv7LAMfUq5AmGEEB77SVvsUJf8bLvFMSEfc8YYyJHF6xNNnjAZQmgqZp76AjT8bD6qKkLZLeR42oi", 0x24,
+		"47a69d7aa0d0b14b22e2ff185b2e8b37effd771fee0c8b3c6a7ff9d53910ffcd",
+		"536bab26fc7101bf23da6b6a39daa83925f5393df58a1bfdcb8edcc388726aae",
+	},
+	{
+		"9zvkxwHbuHxX8B82zA8G9yBh6oKzbXS8viKexKeBCVBwNeP246aVAKSiC1DyVoETYZ11qDdmibSShX88HWGevRbp3G6hKyK", 0x35,
+		"ccc9377cde8377b4190b13b1384c2c3feb697bc98a783ff70bbdf789d623e281",
+		"6763ef0f8d3b41f641db860acbe5360015f00f6d0f05b6b417c0ad4708277b14",
+	},
+	{
+		"Be4mtTzNR3gGe7S9foMPdiLnv7jP2cfR3FB4YiNjNQFh8Q6WGortUdtXgwumP6xRu8MxdozhRjXMf4gCwwwE7NtRQ5jMkJd", 0x3f,
+		"9b5365b83a95d35d8127e5af7b9fa976539907f255ad9254bcd54705a7e6582c",
+		"3b1aba22c7292fb779d907deb9fbd77d4e96286f22053615fa273513938b9acc",
+	},
+}
+
+// turtleAddress is the TurtleCoin address of the keys of the Monero
+// stagenet address above
+const turtleAddress = "TRTLuxw1ZX29fevz8aGUN5V3mvjhb8Lv54S59inVp8LfRcAwjhGupvdPFsf2Y5LNfrExNYPcQgfcWQhdkeasJN6iE1d9aK33sGb"
+
+// publicKey decodes a public key from hex, failing the test if it isn't
+// 32 bytes
+func publicKey(t *testing.T, s string) keys.PublicKey {
+	t.Helper()
+
+	var res keys.PublicKey
+
+	b, err := hex.DecodeString(s)
+
+	if err != nil || len(b) != len(res) {
+		t.Fatalf("bad hex %q", s)
+	}
+
+	copy(res[:], b)
+
+	return res
+}
+
+func TestAddress(t *testing.T) {
+	for _, test := range addressTests {
+		want := Address{
+			Prefix:   test.prefix,
+			SpendKey: publicKey(t, test.spend),
+			ViewKey:  publicKey(t, test.view),
+		}
+
+		network := &config.NetworkParameters{AddressPrefix: test.prefix}
+
+		if a := NewForNetwork(network, want.SpendKey, want.ViewKey); a != want || a.String() != test.address {
+			t.Errorf("NewForNetwork = %v, want %s", a, test.address)
+		}
+
+		if a, err := ParseWithPrefix(test.address, test.prefix); err != nil || a != want {
+			t.Errorf("ParseWithPrefix(%s) = %+v, %v, want %+v", test.address, a, err, want)
+		}
+
+		if err := ValidateForNetwork(test.address, network); err != nil {
+			t.Errorf("ValidateForNetwork(%s) = %v", test.address, err)
+		}
+	}
+
+	stagenet := addressTests[2]
+
+	a := New(publicKey(t, stagenet.spend), publicKey(t, stagenet.view))
+
+	if a.Prefix != TurtleCoinPrefix || a.String() != turtleAddress {
+		t.Errorf("New = %v, want %s", a, turtleAddress)
+	}
+
+	if res, err := Parse(turtleAddress); err != nil || res != a {
+		t.Errorf("Parse(%s) = %+v, %v, want %+v", turtleAddress, res, err, a)
+	}
+
+	if err := Validate(turtleAddress); err != nil {
+		t.Errorf("Validate(%s) = %v", turtleAddress, err)
+	}
+}
+
+// reencode returns the base58 encoding of data with a correct checksum
+func reencode(data []byte) string {
+	return base58.Encode(append(append([]byte(nil), data...), checksum(data)...))
+}
+
+func TestParseErrors(t *testing.T) {
+	data, err := base58.Decode(turtleAddress)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := data[:len(data)-checksumSize]
+	prefixLen := varint.Len(TurtleCoinPrefix)
+
+	// y = 2 is not the y coordinate of a point on the curve
+	notPoint := make([]byte, 32)
+	notPoint[0] = 2
+
+	badSpend := append(append([]byte(nil), body[:prefixLen]...), notPoint...)
+	badSpend = append(badSpend, body[prefixLen+32:]...)
+
+	badView := append(append([]byte(nil), body[:prefixLen+32]...), notPoint...)
+
+	badChecksum := append([]byte(nil), data...)
+	badChecksum[len(badChecksum)-1] ^= 1
+
+	badKey := append([]byte(nil), data...)
+	badKey[prefixLen] ^= 1
+
+	for _, test := range []struct {
+		name    string
+		address string
+		want    error
+	}{
+		{"Monero address", addressTests[0].address, ErrWrongPrefix},
+		{"prefix which never ends", reencode([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}), ErrInvalidPrefix},
+		{"checksum changed", base58.Encode(badChecksum), ErrInvalidChecksum},
+		{"key changed", base58.Encode(badKey), ErrInvalidChecksum},
+		{"byte missing", reencode(body[:len(body)-1]), ErrInvalidLength},
+		{"byte added", reencode(append(append([]byte(nil), body...), 0)), ErrInvalidLength},
+		{"empty", "", ErrInvalidPrefix},
+		{"spend key not a point", reencode(badSpend), ErrInvalidSpendKey},
+		{"view key not a point", reencode(badView), ErrInvalidViewKey},
+	} {
+		if _, err := Parse(test.address); err != test.want {
+			t.Errorf("%s: err = %v, want %v", test.name, err, test.want)
+		}
+
+		if err := Validate(test.address); err != test.want {
+			t.Errorf("%s: Validate = %v, want %v", test.name, err, test.want)
+		}
+	}
+
+	// a character which isn't in the base58 alphabet
+	if _, err := Parse("0" + turtleAddress[1:]); err == nil {
+		t.Error("address with a 0 accepted")
+	} else if _, ok := err.(*base58.InvalidCharacterError); !ok {
+		t.Errorf("address with a 0: err = %v, want a base58.InvalidCharacterError", err)
+	}
+
+	// a last block of one character
+	if _, err := Parse(turtleAddress[:89]); err != base58.ErrInvalidBlockSize {
+		t.Errorf("truncated address: err = %v, want %v", err, base58.ErrInvalidBlockSize)
+	}
+}
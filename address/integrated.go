@@ -0,0 +1,149 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package address
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/turtlecoin/go-turtlecoin/config"
+)
+
+// An integrated address holds a payment ID as well as the keys, so a
+// single string tells the sender both where to pay and what payment ID
+// to use. The payment ID is put in front of the keys, as 64 hex
+// characters rather than the 32 bytes they stand for:
+//
+//	prefix (varint) || payment ID (64) || public spend key (32) || public view key (32) || checksum (4)
+
+// paymentIDSize is the length of a payment ID in hex
+const paymentIDSize = 64
+
+// ErrInvalidPaymentID is returned when a payment ID is not 64 hex
+// characters
+var ErrInvalidPaymentID = errors.New("payment ID must be 64 hex characters")
+
+// checkPaymentID returns ErrInvalidPaymentID if paymentID is not 64 hex
+// characters
+func checkPaymentID(paymentID string) error {
+	if len(paymentID) != paymentIDSize {
+		return ErrInvalidPaymentID
+	}
+
+	if _, err := hex.DecodeString(paymentID); err != nil {
+		return ErrInvalidPaymentID
+	}
+
+	return nil
+}
+
+// Integrated returns the integrated address of a with the payment ID
+// paymentID, which is 64 hex characters. The prefix is the same as the
+// prefix of a, as on TurtleCoin, see IntegratedForNetwork.
+func (a Address) Integrated(paymentID string) (string, error) {
+	return a.IntegratedWithPrefix(a.Prefix, paymentID)
+}
+
+// IntegratedForNetwork returns the integrated address of a with the
+// payment ID paymentID on network
+func (a Address) IntegratedForNetwork(network *config.NetworkParameters, paymentID string) (string, error) {
+	return a.IntegratedWithPrefix(network.IntegratedAddressPrefix, paymentID)
+}
+
+// IntegratedWithPrefix returns the integrated address of a with the
+// payment ID paymentID and the given prefix
+func (a Address) IntegratedWithPrefix(prefix uint64, paymentID string) (string, error) {
+	if err := checkPaymentID(paymentID); err != nil {
+		return "", err
+	}
+
+	data := make([]byte, 0, paymentIDSize+64)
+
+	data = append(data, paymentID...)
+	data = append(data, a.SpendKey[:]...)
+	data = append(data, a.ViewKey[:]...)
+
+	return encode(prefix, data), nil
+}
+
+// ParseIntegrated decodes and validates a TurtleCoin integrated address,
+// returning the address and the payment ID it holds
+func ParseIntegrated(s string) (Address, string, error) {
+	return ParseIntegratedForNetwork(s, config.Mainnet)
+}
+
+// ParseIntegratedForNetwork decodes and validates an integrated address on
+// network. The address returned has the standard address prefix of
+// network.
+func ParseIntegratedForNetwork(s string, network *config.NetworkParameters) (Address, string, error) {
+	a, paymentID, err := ParseIntegratedWithPrefix(s, network.IntegratedAddressPrefix)
+
+	if err != nil {
+		return Address{}, "", err
+	}
+
+	a.Prefix = network.AddressPrefix
+
+	return a, paymentID, nil
+}
+
+// ParseIntegratedWithPrefix decodes and validates an integrated address
+// with the given prefix, returning the address and the payment ID it holds
+func ParseIntegratedWithPrefix(s string, prefix uint64) (Address, string, error) {
+	data, err := decode(s, prefix, paymentIDSize+64)
+
+	if err != nil {
+		return Address{}, "", err
+	}
+
+	paymentID := string(data[:paymentIDSize])
+
+	if err := checkPaymentID(paymentID); err != nil {
+		return Address{}, "", err
+	}
+
+	a, err := fromKeys(prefix, data[paymentIDSize:])
+
+	if err != nil {
+		return Address{}, "", err
+	}
+
+	return a, paymentID, nil
+}
+
+// ParseAny decodes and validates either a TurtleCoin address or integrated
+// address. The payment ID is empty for a standard address.
+func ParseAny(s string) (Address, string, error) {
+	return ParseAnyForNetwork(s, config.Mainnet)
+}
+
+// ParseAnyForNetwork decodes and validates either an address or integrated
+// address on network. The payment ID is empty for a standard address.
+func ParseAnyForNetwork(s string, network *config.NetworkParameters) (Address, string, error) {
+	a, err := ParseForNetwork(s, network)
+
+	if err != ErrInvalidLength {
+		return a, "", err
+	}
+
+	return ParseIntegratedForNetwork(s, network)
+}
+
+// ParseAnyWithPrefix decodes and validates either an address or integrated
+// address with the given prefix. The payment ID is empty for a standard
+// address.
+func ParseAnyWithPrefix(s string, prefix uint64) (Address, string, error) {
+	a, err := ParseWithPrefix(s, prefix)
+
+	if err != ErrInvalidLength {
+		return a, "", err
+	}
+
+	return ParseIntegratedWithPrefix(s, prefix)
+}
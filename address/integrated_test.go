@@ -0,0 +1,175 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package address
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/base58"
+	"github.com/turtlecoin/go-turtlecoin/config"
+	"github.com/turtlecoin/go-turtlecoin/varint"
+)
+
+// integratedTest is the integrated address of turtleAddress with a
+// payment ID
+var integratedTest = struct {
+	paymentID string
+	address   string
+}{
+	paymentID: "1d2a6e9b3f5c7b8a0e4d6f2c9b1a3e5d7c9b2a4f6e8d0c1b3a5f7e9d2c4b6a80",
+	address:   "TRTLuxjrrL8A6horsAnUa6AGHsw4kjiJfA6jwrg8UMda9bbkq9CyHvq9RkHQCMgsQo96RJqDuXP2dAGQUsu3qkKbA6Z1Ls2zsjA9fevz8aGUN5V3mvjhb8Lv54S59inVp8LfRcAwjhGupvdPFsf2Y5LNfrExNYPcQgfcWQhdkeasJN6iE1d9aKuxDNw",
+}
+
+func TestIntegrated(t *testing.T) {
+	a, err := Parse(turtleAddress)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := a.Integrated(integratedTest.paymentID)
+
+	if err != nil || s != integratedTest.address {
+		t.Fatalf("Integrated = %s, %v, want %s", s, err, integratedTest.address)
+	}
+
+	// the payment ID is held as its hex characters, in front of the keys
+	data, err := base58.Decode(s)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := varint.Append(nil, TurtleCoinPrefix)
+	want = append(want, integratedTest.paymentID...)
+	want = append(want, a.SpendKey[:]...)
+	want = append(want, a.ViewKey[:]...)
+
+	if !bytes.Equal(data[:len(want)], want) || len(data) != len(want)+checksumSize {
+		t.Errorf("integrated address decodes to %x, want %x and a checksum", data, want)
+	}
+
+	for _, parse := range []func(string) (Address, string, error){ParseIntegrated, ParseAny} {
+		if res, paymentID, err := parse(s); err != nil || res != a || paymentID != integratedTest.paymentID {
+			t.Errorf("parsing %s = %+v, %q, %v", s, res, paymentID, err)
+		}
+	}
+
+	if res, paymentID, err := ParseAny(turtleAddress); err != nil || res != a || paymentID != "" {
+		t.Errorf("ParseAny(%s) = %+v, %q, %v", turtleAddress, res, paymentID, err)
+	}
+
+	// upper case hex is kept as it is
+	upper := strings.ToUpper(integratedTest.paymentID)
+
+	s, err = a.Integrated(upper)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, paymentID, err := ParseIntegrated(s); err != nil || paymentID != upper {
+		t.Errorf("upper case payment ID: ParseIntegrated = %q, %v, want %q", paymentID, err, upper)
+	}
+}
+
+// TestIntegratedForNetwork checks a network with a separate integrated
+// address prefix, as Monero has
+func TestIntegratedForNetwork(t *testing.T) {
+	network := &config.NetworkParameters{AddressPrefix: 0x12, IntegratedAddressPrefix: 0x13}
+
+	a, err := ParseForNetwork(addressTests[0].address, network)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := a.IntegratedForNetwork(network, integratedTest.paymentID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res, paymentID, err := ParseIntegratedForNetwork(s, network); err != nil || res != a || paymentID != integratedTest.paymentID {
+		t.Errorf("ParseIntegratedForNetwork = %+v, %q, %v, want %+v", res, paymentID, err, a)
+	}
+
+	if res, paymentID, err := ParseAnyForNetwork(s, network); err != nil || res != a || paymentID != integratedTest.paymentID {
+		t.Errorf("ParseAnyForNetwork = %+v, %q, %v, want %+v", res, paymentID, err, a)
+	}
+
+	if res, _, err := ParseIntegratedWithPrefix(s, network.IntegratedAddressPrefix); err != nil || res.Prefix != network.IntegratedAddressPrefix {
+		t.Errorf("ParseIntegratedWithPrefix = %+v, %v", res, err)
+	}
+
+	if _, _, err := ParseAnyWithPrefix(s, network.AddressPrefix); err != ErrWrongPrefix {
+		t.Errorf("integrated address with the standard prefix: err = %v, want %v", err, ErrWrongPrefix)
+	}
+
+	if _, err := ParseForNetwork(s, network); err != ErrInvalidLength {
+		t.Errorf("integrated address parsed as a standard address: err = %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+func TestIntegratedErrors(t *testing.T) {
+	a, err := Parse(turtleAddress)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, paymentID := range []string{
+		"",
+		integratedTest.paymentID[:63],
+		integratedTest.paymentID + "0",
+		"zz" + integratedTest.paymentID[2:],
+	} {
+		if _, err := a.Integrated(paymentID); err != ErrInvalidPaymentID {
+			t.Errorf("payment ID %q: err = %v, want %v", paymentID, err, ErrInvalidPaymentID)
+		}
+	}
+
+	// y = 2 is not the y coordinate of a point on the curve
+	notPoint := make([]byte, 32)
+	notPoint[0] = 2
+
+	notHex := append([]byte("zz"+integratedTest.paymentID[2:]), a.SpendKey[:]...)
+	notHex = append(notHex, a.ViewKey[:]...)
+
+	badSpend := append([]byte(integratedTest.paymentID), notPoint...)
+	badSpend = append(badSpend, a.ViewKey[:]...)
+
+	badView := append([]byte(integratedTest.paymentID), a.SpendKey[:]...)
+	badView = append(badView, notPoint...)
+
+	turtleKeys := append(a.SpendKey[:], a.ViewKey[:]...)
+
+	for _, test := range []struct {
+		name    string
+		address string
+		want    error
+	}{
+		{"payment ID not hex", encode(TurtleCoinPrefix, notHex), ErrInvalidPaymentID},
+		{"spend key not a point", encode(TurtleCoinPrefix, badSpend), ErrInvalidSpendKey},
+		{"view key not a point", encode(TurtleCoinPrefix, badView), ErrInvalidViewKey},
+		{"standard address", turtleAddress, ErrInvalidLength},
+		{"another prefix", encode(0x13, append([]byte(integratedTest.paymentID), turtleKeys...)), ErrWrongPrefix},
+		{"checksum changed", integratedTest.address[:len(integratedTest.address)-1] + "x", ErrInvalidChecksum},
+	} {
+		if _, _, err := ParseIntegrated(test.address); err != test.want {
+			t.Errorf("%s: err = %v, want %v", test.name, err, test.want)
+		}
+	}
+
+	if _, _, err := ParseAny(encode(TurtleCoinPrefix, notHex)); err != ErrInvalidPaymentID {
+		t.Errorf("ParseAny: err = %v, want %v", err, ErrInvalidPaymentID)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+// Package base58 implements the block based base58 encoding CryptoNote
+// uses for addresses. It is not compatible with the base58 of Bitcoin.
+//
+// The data is split into 8 byte blocks, each encoded as a big endian
+// number in exactly 11 characters, so the length of the encoding only
+// depends on the length of the data. The last block may be shorter, its
+// length sets the number of characters it is encoded in.
+package base58
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+)
+
+// Alphabet is the characters of the encoding, in order of value
+const Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+const (
+	// BlockSize is the number of bytes in a full block
+	BlockSize = 8
+	// EncodedBlockSize is the number of characters a full block is
+	// encoded in
+	EncodedBlockSize = 11
+)
+
+// encodedBlockSizes[n] is the number of characters a block of n bytes is
+// encoded in
+var encodedBlockSizes = [BlockSize + 1]int{0, 2, 3, 5, 6, 7, 9, 10, 11}
+
+// ErrInvalidBlockSize is returned when the length of an encoding doesn't
+// match any length of data
+var ErrInvalidBlockSize = errors.New("base58: invalid encoded block size")
+
+// ErrOverflow is returned when a block decodes to a number too large for
+// its size
+var ErrOverflow = errors.New("base58: encoded block overflows")
+
+// InvalidCharacterError is returned when decoding a string containing a
+// character which isn't in the alphabet
+type InvalidCharacterError struct {
+	// Position is the byte offset of the character
+	Position int
+	Char     byte
+}
+
+func (e *InvalidCharacterError) Error() string {
+	return "base58: invalid character " + strconv.QuoteRune(rune(e.Char)) + " at position " + strconv.Itoa(e.Position)
+}
+
+// decodeMap maps each character to its value, -1 for characters which
+// aren't in the alphabet
+var decodeMap [256]int8
+
+func init() {
+	for i := range decodeMap {
+		decodeMap[i] = -1
+	}
+
+	for i := 0; i < len(Alphabet); i++ {
+		decodeMap[Alphabet[i]] = int8(i)
+	}
+}
+
+// decodedBlockSize returns the number of bytes encoded in n characters,
+// or -1 if no block is encoded in n characters
+func decodedBlockSize(n int) int {
+	for size, encoded := range encodedBlockSizes {
+		if encoded == n {
+			return size
+		}
+	}
+
+	return -1
+}
+
+// EncodedLen returns the length of the encoding of n bytes
+func EncodedLen(n int) int {
+	return n/BlockSize*EncodedBlockSize + encodedBlockSizes[n%BlockSize]
+}
+
+// encodeBlock encodes block into dst, which is encodedBlockSizes[len(block)]
+// characters long
+func encodeBlock(dst []byte, block []byte) {
+	var num uint64
+
+	for _, b := range block {
+		num = num<<8 | uint64(b)
+	}
+
+	for i := len(dst) - 1; i >= 0; i-- {
+		dst[i] = Alphabet[num%58]
+		num /= 58
+	}
+}
+
+// Encode returns the base58 encoding of data
+func Encode(data []byte) string {
+	res := make([]byte, EncodedLen(len(data)))
+
+	for i, j := 0, 0; i < len(data); i, j = i+BlockSize, j+EncodedBlockSize {
+		end := i + BlockSize
+
+		if end > len(data) {
+			end = len(data)
+		}
+
+		block := data[i:end]
+
+		encodeBlock(res[j:j+encodedBlockSizes[len(block)]], block)
+	}
+
+	return string(res)
+}
+
+// decodeBlock decodes the characters of block, starting at offset in the
+// whole string, into dst
+func decodeBlock(dst []byte, block string, offset int) error {
+	var num uint64
+
+	for i := 0; i < len(block); i++ {
+		digit := decodeMap[block[i]]
+
+		if digit < 0 {
+			return &InvalidCharacterError{Position: offset + i, Char: block[i]}
+		}
+
+		// 11 characters can encode more than 64 bits
+		hi, lo := bits.Mul64(num, 58)
+
+		num = lo + uint64(digit)
+
+		if hi != 0 || num < lo {
+			return ErrOverflow
+		}
+	}
+
+	if len(dst) < BlockSize && num>>(8*uint(len(dst))) != 0 {
+		return ErrOverflow
+	}
+
+	for i := len(dst) - 1; i >= 0; i-- {
+		dst[i] = byte(num)
+		num >>= 8
+	}
+
+	return nil
+}
+
+// Decode returns the data encoded in s
+func Decode(s string) ([]byte, error) {
+	fullBlocks := len(s) / EncodedBlockSize
+	lastSize := decodedBlockSize(len(s) % EncodedBlockSize)
+
+	if lastSize < 0 {
+		return nil, ErrInvalidBlockSize
+	}
+
+	res := make([]byte, fullBlocks*BlockSize+lastSize)
+
+	for i, j := 0, 0; i < len(s); i, j = i+EncodedBlockSize, j+BlockSize {
+		end := i + EncodedBlockSize
+
+		if end > len(s) {
+			end = len(s)
+		}
+
+		block := s[i:end]
+
+		if err := decodeBlock(res[j:j+decodedBlockSize(len(block))], block, i); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,107 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package base58
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+)
+
+// encodeTests cover empty data, one byte blocks, full blocks and a short
+// last block, at the smallest and largest values of each
+var encodeTests = []struct {
+	data    string
+	encoded string
+}{
+	{"", ""},
+	{"00", "11"},
+	{"39", "1z"},
+	{"ff", "5Q"},
+	{"0000000000000000", "11111111111"},
+	{"ffffffffffffffff", "jpXCZedGfVQ"},
+	{"ffffffffffffffffff", "jpXCZedGfVQ5Q"},
+	{"000000000000000000", "1111111111111"},
+}
+
+func TestEncode(t *testing.T) {
+	for _, test := range encodeTests {
+		data, _ := hex.DecodeString(test.data)
+
+		if encoded := Encode(data); encoded != test.encoded {
+			t.Errorf("Encode(%s) = %q, want %q", test.data, encoded, test.encoded)
+		}
+
+		decoded, err := Decode(test.encoded)
+
+		if err != nil || !bytes.Equal(decoded, data) {
+			t.Errorf("Decode(%q) = %x, %v, want %s", test.encoded, decoded, err, test.data)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	roundTrip := func(data []byte) bool {
+		encoded := Encode(data)
+
+		if len(encoded) != EncodedLen(len(data)) {
+			return false
+		}
+
+		decoded, err := Decode(encoded)
+
+		return err == nil && bytes.Equal(decoded, data)
+	}
+
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, test := range []struct {
+		encoded string
+		err     error
+	}{
+		{"1", ErrInvalidBlockSize},
+		{"1111", ErrInvalidBlockSize},
+		{"11111111", ErrInvalidBlockSize},
+		{"111111111111", ErrInvalidBlockSize},
+		// 256 in a one byte block
+		{"5R", ErrOverflow},
+		// 2^64 in a full block
+		{"jpXCZedGfVR", ErrOverflow},
+		// more than 2^64 * 58 in a full block
+		{"zzzzzzzzzzz", ErrOverflow},
+	} {
+		if _, err := Decode(test.encoded); err != test.err {
+			t.Errorf("Decode(%q): err = %v, want %v", test.encoded, err, test.err)
+		}
+	}
+
+	for _, test := range []struct {
+		encoded  string
+		position int
+		char     byte
+	}{
+		{"0z", 0, '0'},
+		{"1O", 1, 'O'},
+		{"11111111111I1", 11, 'I'},
+		{"1111111111l", 10, 'l'},
+	} {
+		_, err := Decode(test.encoded)
+
+		invalid, ok := err.(*InvalidCharacterError)
+
+		if !ok || invalid.Position != test.position || invalid.Char != test.char {
+			t.Errorf("Decode(%q): err = %v, want invalid character %q at %d", test.encoded, err, test.char, test.position)
+		}
+	}
+}
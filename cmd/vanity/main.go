@@ -0,0 +1,84 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+// Command vanity searches for a TurtleCoin address starting with a chosen
+// prefix and prints it with its keys and mnemonic seed.
+//
+//	vanity -prefix TRTLv2She11 -workers 8
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/turtlecoin/go-turtlecoin/config"
+	"github.com/turtlecoin/go-turtlecoin/crypto/mnemonics"
+	"github.com/turtlecoin/go-turtlecoin/vanity"
+)
+
+func main() {
+	prefix := flag.String("prefix", "", "prefix the address must start with, including the fixed start of every address")
+	workers := flag.Int("workers", 0, "number of goroutines to search with, 0 for one per CPU")
+	interval := flag.Duration("interval", 5*time.Second, "time between progress updates")
+	testnet := flag.Bool("testnet", false, "search for a testnet address")
+
+	flag.Parse()
+
+	network := config.Mainnet
+
+	if *testnet {
+		network = config.Testnet
+	}
+
+	expected, err := vanity.ExpectedAttempts(network, *prefix)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vanity:", err)
+		fmt.Fprintf(os.Stderr, "every address starts with %s and prefixes can be up to %d characters long\n",
+			vanity.FixedPrefix(network), vanity.MaxPrefixLength(network))
+		os.Exit(2)
+	}
+
+	fmt.Fprintf(os.Stderr, "about %.0f keys must be tried to find a match\n", expected)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	res, err := vanity.Search(ctx, *prefix, vanity.Options{
+		Network:          network,
+		Workers:          *workers,
+		ProgressInterval: *interval,
+		Progress: func(p vanity.Progress) {
+			fmt.Fprintf(os.Stderr, "%d keys tried in %s, %.0f keys/s, about %s per match\n",
+				p.Attempts, p.Elapsed.Round(time.Second), p.Rate, p.Remaining.Round(time.Second))
+		},
+	})
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vanity:", err)
+		os.Exit(1)
+	}
+
+	mnemonic, err := mnemonics.PrivateKeyToMnemonic(res.SpendSecret)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vanity:", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Address:          ", res.Address)
+	fmt.Println("Private spend key:", hex.EncodeToString(res.SpendSecret[:]))
+	fmt.Println("Private view key: ", hex.EncodeToString(res.ViewSecret[:]))
+	fmt.Println("Mnemonic seed:    ", mnemonic)
+	fmt.Println("Keys tried:       ", res.Attempts)
+}
@@ -0,0 +1,176 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+// Package config holds the parameters which differ between CryptoNote
+// networks, so the rest of the library can be used by forks and test
+// networks as well as TurtleCoin
+package config
+
+import "time"
+
+// HashAlgorithm is a proof of work hashing algorithm
+type HashAlgorithm int
+
+const (
+	// CryptoNight is the original CryptoNight algorithm
+	CryptoNight HashAlgorithm = iota
+	// CryptoNightLiteV1 is CryptoNight Lite, variant 1
+	CryptoNightLiteV1
+	// CryptoNightTurtleLiteV2 is CryptoNight Turtle Lite, variant 2
+	CryptoNightTurtleLiteV2
+)
+
+// String returns the name of the algorithm
+func (h HashAlgorithm) String() string {
+	switch h {
+	case CryptoNight:
+		return "CryptoNight"
+	case CryptoNightLiteV1:
+		return "CryptoNight Lite v1"
+	case CryptoNightTurtleLiteV2:
+		return "CryptoNight Turtle Lite v2"
+	}
+
+	return "unknown"
+}
+
+// HashAlgorithmUpgrade is a height at which the proof of work algorithm
+// changes
+type HashAlgorithmUpgrade struct {
+	Height    uint64
+	Algorithm HashAlgorithm
+}
+
+// NetworkParameters are the parameters of a network
+type NetworkParameters struct {
+	// Name is the name of the network
+	Name string
+	// Ticker is the ticker symbol of the coin
+	Ticker string
+
+	// AddressPrefix is the varint prefix of addresses
+	AddressPrefix uint64
+	// IntegratedAddressPrefix is the varint prefix of integrated addresses
+	IntegratedAddressPrefix uint64
+
+	// DecimalPlaces is the number of decimal places of the coin, amounts
+	// are in atomic units of 10^-DecimalPlaces coins
+	DecimalPlaces int
+	// DifficultyTarget is the time between blocks the difficulty aims for
+	DifficultyTarget time.Duration
+
+	// HashAlgorithms is the proof of work algorithm schedule, in order of
+	// height. The first upgrade is at height 0.
+	HashAlgorithms []HashAlgorithmUpgrade
+
+	// P2PPort is the default port of the peer to peer protocol
+	P2PPort uint16
+	// RPCPort is the default port of the daemon RPC interface
+	RPCPort uint16
+	// NetworkID is sent in the peer to peer handshake, peers with a
+	// different ID are from another network
+	NetworkID [16]byte
+
+	// GenesisCoinbaseTx is the hex encoding of the coinbase transaction of
+	// the genesis block
+	GenesisCoinbaseTx string
+	// GenesisNonce is the nonce of the genesis block
+	GenesisNonce uint32
+}
+
+// TurtleCoinAddressPrefix is the address prefix of TurtleCoin, which makes
+// addresses start with TRTL
+const TurtleCoinAddressPrefix = 3914525
+
+// CurrentTransactionVersion is the newest transaction version, later
+// versions can't be decoded
+const CurrentTransactionVersion = 1
+
+// turtleCoinGenesisCoinbaseTx is the coinbase transaction of the TurtleCoin
+// genesis block, shared by mainnet and testnet
+const turtleCoinGenesisCoinbaseTx = "010a01ff000188f3b501029b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd088071210142694232c5b04151d9e4c27d31ec7a68ea568b19488cfcb422659a07a0e44dd5"
+
+// Mainnet is the TurtleCoin network. Forks fill in their own
+// NetworkParameters.
+var Mainnet = &NetworkParameters{
+	Name:   "TurtleCoin",
+	Ticker: "TRTL",
+
+	AddressPrefix:           TurtleCoinAddressPrefix,
+	IntegratedAddressPrefix: TurtleCoinAddressPrefix,
+
+	DecimalPlaces:    2,
+	DifficultyTarget: 30 * time.Second,
+
+	HashAlgorithms: []HashAlgorithmUpgrade{
+		{Height: 0, Algorithm: CryptoNight},
+		{Height: 350000, Algorithm: CryptoNightLiteV1},
+		{Height: 1200000, Algorithm: CryptoNightTurtleLiteV2},
+	},
+
+	P2PPort: 11897,
+	RPCPort: 11898,
+	NetworkID: [16]byte{
+		0xb5, 0x0c, 0x4a, 0x6c, 0xcf, 0x52, 0x57, 0x41,
+		0x65, 0xf9, 0x91, 0xa4, 0xb6, 0xc1, 0x43, 0xe9},
+
+	GenesisCoinbaseTx: turtleCoinGenesisCoinbaseTx,
+	GenesisNonce:      70,
+}
+
+// Testnet is the network the reference daemon runs with --testnet. It
+// keeps the mainnet addresses, ports and genesis transaction, but the
+// first byte of the network ID and the genesis nonce are one higher, so
+// its peers and blocks can't be mixed up with mainnet's.
+var Testnet = &NetworkParameters{
+	Name:   "TurtleCoin testnet",
+	Ticker: "TRTL",
+
+	AddressPrefix:           TurtleCoinAddressPrefix,
+	IntegratedAddressPrefix: TurtleCoinAddressPrefix,
+
+	DecimalPlaces:    2,
+	DifficultyTarget: 30 * time.Second,
+
+	HashAlgorithms: Mainnet.HashAlgorithms,
+
+	P2PPort: 11897,
+	RPCPort: 11898,
+	NetworkID: [16]byte{
+		0xb6, 0x0c, 0x4a, 0x6c, 0xcf, 0x52, 0x57, 0x41,
+		0x65, 0xf9, 0x91, 0xa4, 0xb6, 0xc1, 0x43, 0xe9},
+
+	GenesisCoinbaseTx: turtleCoinGenesisCoinbaseTx,
+	GenesisNonce:      71,
+}
+
+// HashAlgorithm returns the proof of work algorithm of the block at height
+func (p *NetworkParameters) HashAlgorithm(height uint64) HashAlgorithm {
+	res := CryptoNight
+
+	for _, upgrade := range p.HashAlgorithms {
+		if height < upgrade.Height {
+			break
+		}
+
+		res = upgrade.Algorithm
+	}
+
+	return res
+}
+
+// AtomicUnits returns the number of atomic units in one coin
+func (p *NetworkParameters) AtomicUnits() uint64 {
+	res := uint64(1)
+
+	for i := 0; i < p.DecimalPlaces; i++ {
+		res *= 10
+	}
+
+	return res
+}
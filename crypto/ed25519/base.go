@@ -0,0 +1,175 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package ed25519
+
+import "sync"
+
+// base[i][j] = (j+1) * 256^i * B, the table used by GeScalarMultBase.
+// ref10 ships this as data, here it is generated the first time it is
+// needed, which takes a few milliseconds.
+var base [32][8]PreComputedGroupElement
+
+var baseOnce sync.Once
+
+// Fills in the base table. Each row is built from the last with 8
+// doublings, the entries are converted to precomputed form with a
+// single inversion per row.
+func generateBase() {
+	var t CompletedGroupElement
+	var row ExtendedGroupElement
+	var multiples [8]ExtendedGroupElement
+	var c CachedGroupElement
+
+	row = basePoint
+
+	for i := range base {
+		multiples[0] = row
+		row.ToCached(&c)
+
+		for j := 1; j < 8; j++ {
+			GeAdd(&t, &multiples[j-1], &c)
+			t.ToExtended(&multiples[j])
+		}
+
+		batchToPreComputed(base[i][:], multiples[:])
+
+		// 256 * row, via 5 doublings of 8 * row
+		row = multiples[7]
+
+		for j := 0; j < 5; j++ {
+			row.Double(&t)
+			t.ToExtended(&row)
+		}
+	}
+}
+
+// Converts points to precomputed form, sharing one inversion between
+// all of them (Montgomery's trick)
+func batchToPreComputed(r []PreComputedGroupElement, points []ExtendedGroupElement) {
+	var acc, inv, x, y FieldElement
+
+	prefix := make([]FieldElement, len(points))
+
+	FeOne(&acc)
+
+	for i := range points {
+		prefix[i] = acc
+		FeMul(&acc, &acc, &points[i].Z)
+	}
+
+	FeInvert(&inv, &acc)
+
+	for i := len(points) - 1; i >= 0; i-- {
+		var recip FieldElement
+
+		FeMul(&recip, &inv, &prefix[i])
+		FeMul(&inv, &inv, &points[i].Z)
+
+		FeMul(&x, &points[i].X, &recip)
+		FeMul(&y, &points[i].Y, &recip)
+		FeAdd(&r[i].yPlusX, &y, &x)
+		FeSub(&r[i].yMinusX, &y, &x)
+		FeMul(&r[i].xy2d, &x, &y)
+		FeMul(&r[i].xy2d, &r[i].xy2d, &feD2)
+	}
+}
+
+// Zero sets p to the identity
+func (p *PreComputedGroupElement) Zero() {
+	FeOne(&p.yPlusX)
+	FeOne(&p.yMinusX)
+	FeZero(&p.xy2d)
+}
+
+// Replaces p with u if b == 1, without branching on b
+func (p *PreComputedGroupElement) cmov(u *PreComputedGroupElement, b int32) {
+	FeCMove(&p.yPlusX, &u.yPlusX, b)
+	FeCMove(&p.yMinusX, &u.yMinusX, b)
+	FeCMove(&p.xy2d, &u.xy2d, b)
+}
+
+// Sets t = b * 256^pos * B in constant time, b in -8..8
+func selectPoint(t *PreComputedGroupElement, pos int, b int32) {
+	var minusT PreComputedGroupElement
+
+	bNegative := negative(b)
+	bAbs := b - (((-bNegative) & b) << 1)
+
+	t.Zero()
+
+	for i := int32(0); i < 8; i++ {
+		t.cmov(&base[pos][i], equal(bAbs, i+1))
+	}
+
+	FeCopy(&minusT.yPlusX, &t.yMinusX)
+	FeCopy(&minusT.yMinusX, &t.yPlusX)
+	FeNeg(&minusT.xy2d, &t.xy2d)
+
+	t.cmov(&minusT, bNegative)
+}
+
+// GeScalarMultBase sets h = a * B, where B is the ed25519 base point
+// and a = a[0]+256*a[1]+...+256^31 a[31]. a[31] must be at most 127.
+//
+// a is recoded into 64 signed radix 16 digits e[i] in -8..8, so
+// a = sum e[i] * 16^i. The odd digits are added first from the table,
+// the sum is multiplied by 16, then the even digits are added. Lookups
+// are constant time, so a may be secret.
+func GeScalarMultBase(h *ExtendedGroupElement, a *[32]byte) {
+	var e [64]int32
+	var carry int32
+	var r CompletedGroupElement
+	var s ProjectiveGroupElement
+	var t PreComputedGroupElement
+
+	baseOnce.Do(generateBase)
+
+	for i, v := range a {
+		e[2*i] = int32(v & 15)
+		e[2*i+1] = int32((v >> 4) & 15)
+	}
+
+	// each e[i] is between 0 and 15, e[63] is between 0 and 7
+
+	for i := 0; i < 63; i++ {
+		e[i] += carry
+		carry = e[i] + 8
+		carry >>= 4
+		e[i] -= carry << 4
+	}
+
+	e[63] += carry
+
+	// each e[i] is between -8 and 8
+
+	h.Zero()
+
+	for i := 1; i < 64; i += 2 {
+		selectPoint(&t, i/2, e[i])
+		geMixedAdd(&r, h, &t)
+		r.ToExtended(h)
+	}
+
+	h.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToExtended(h)
+
+	for i := 0; i < 64; i += 2 {
+		selectPoint(&t, i/2, e[i])
+		geMixedAdd(&r, h, &t)
+		r.ToExtended(h)
+	}
+}
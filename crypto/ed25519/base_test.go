@@ -0,0 +1,111 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package ed25519
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+// randomScalar returns a random scalar reduced mod l
+func randomScalar(tb testing.TB) [32]byte {
+	var wide [64]byte
+	var res [32]byte
+
+	if _, err := rand.Read(wide[:]); err != nil {
+		tb.Fatal(err)
+	}
+
+	ScReduce(&res, &wide)
+
+	return res
+}
+
+// TestGeScalarMultBase checks the table based multiplication agrees with
+// the generic one on the base point
+func TestGeScalarMultBase(t *testing.T) {
+	var p, q ExtendedGroupElement
+	var a, got, want, base [32]byte
+
+	scalars := [][32]byte{{}, {1}, {2}, {8}, {15}, {16}, {0xff}}
+
+	// all digits 8 or -8 after recoding, and the largest allowed a[31]
+	for i := range a {
+		a[i] = 0x88
+	}
+
+	scalars = append(scalars, a)
+
+	for i := range a {
+		a[i] = 0xff
+	}
+
+	a[31] = 0x7f
+	scalars = append(scalars, a)
+
+	// l - 1
+	scalars = append(scalars, [32]byte{
+		0xec, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+		0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	})
+
+	for i := 0; i < 64; i++ {
+		scalars = append(scalars, randomScalar(t))
+	}
+
+	for _, a := range scalars {
+		GeScalarMultBase(&p, &a)
+		GeScalarMult(&q, &a, &basePoint)
+
+		p.ToBytes(&got)
+		q.ToBytes(&want)
+
+		if got != want {
+			t.Errorf("%x * B: GeScalarMultBase gives %x, GeScalarMult %x", a, got, want)
+		}
+	}
+
+	// 1 * B is B
+	GeScalarMultBase(&p, &[32]byte{1})
+	p.ToBytes(&got)
+	basePoint.ToBytes(&base)
+
+	if got != base {
+		t.Errorf("1 * B = %x, want %x", got, base)
+	}
+}
+
+func BenchmarkGeScalarMultBase(b *testing.B) {
+	var p ExtendedGroupElement
+
+	a := randomScalar(b)
+
+	// build the table outside the timed loop
+	GeScalarMultBase(&p, &a)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		GeScalarMultBase(&p, &a)
+	}
+}
+
+func BenchmarkGeScalarMult(b *testing.B) {
+	var p ExtendedGroupElement
+
+	a := randomScalar(b)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		GeScalarMult(&p, &a, &basePoint)
+	}
+}
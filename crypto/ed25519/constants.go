@@ -1,28 +1,28 @@
 package ed25519
 
-var feD = []int{
+var feD = FieldElement{
 	-10913610, 13857413, -15372611, 6949391, 114729, -8787816, -6275908, -3247719, -18696448, -12055116}
 
-var feD2 = []int{
+var feD2 = FieldElement{
 	-21827239, -5839606, -30745221, 13898782, 229458, 15978800, -12551817, -6495438, 29715968, 9444199}
 
-var feSqrtm1 = []int{
+var feSqrtm1 = FieldElement{
 	-32595792, -7943725, 9377950, 3500415, 12389472, -272473, -25146209, -2005654, 326686, 11406482}
 
-var feMa = []int{
+var feMa = FieldElement{
 	-486662, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 
-var feMa2 = []int{
+var feMa2 = FieldElement{
 	-12721188, -3529, 0, 0, 0, 0, 0, 0, 0, 0}
 
-var feFfffb1 = []int{
+var feFfffb1 = FieldElement{
 	-31702527, -2466483, -26106795, -12203692, -12169197, -321052, 14850977, -10296299, -16929438, -407568}
 
-var feFfffb2 = []int{
+var feFfffb2 = FieldElement{
 	8166131, -6741800, -17040804, 3154616, 21461005, 1466302, -30876704, -6368709, 10503587, -13363080}
 
-var feFfffb3 = []int{
+var feFfffb3 = FieldElement{
 	-13620103, 14639558, 4532995, 7679154, 16815101, -15883539, -22863840, -14813421, 13716513, -6477756}
 
-var feFfffb4 = []int{
+var feFfffb4 = FieldElement{
 	-21786234, -12173074, 21573800, 4524538, -4645904, 16204591, 8012863, -8444712, 3212926, 6885324}
@@ -0,0 +1,505 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+// This is a port of the ref10 curve25519 code used by CryptoNote
+// (crypto-ops.c), including the Monero specific additions
+
+package ed25519
+
+// FieldElement represents an element of the field GF(2^255 - 19). An element
+// t, entries t[0]...t[9], represents the integer t[0]+2^26 t[1]+2^51 t[2]+
+// 2^77 t[3]+2^102 t[4]+...+2^230 t[9]. Bounds on each t[i] vary depending
+// on context.
+type FieldElement [10]int32
+
+var zero FieldElement
+
+// FeZero sets fe to 0
+func FeZero(fe *FieldElement) {
+	copy(fe[:], zero[:])
+}
+
+// FeOne sets fe to 1
+func FeOne(fe *FieldElement) {
+	FeZero(fe)
+	fe[0] = 1
+}
+
+// FeAdd sets dst to a + b
+func FeAdd(dst, a, b *FieldElement) {
+	for i := range dst {
+		dst[i] = a[i] + b[i]
+	}
+}
+
+// FeSub sets dst to a - b
+func FeSub(dst, a, b *FieldElement) {
+	for i := range dst {
+		dst[i] = a[i] - b[i]
+	}
+}
+
+// FeCopy copies src into dst
+func FeCopy(dst, src *FieldElement) {
+	copy(dst[:], src[:])
+}
+
+// FeCMove replaces (f,g) with (g,g) if b == 1, and leaves (f,g)
+// untouched if b == 0, without branching on b
+func FeCMove(f, g *FieldElement, b int32) {
+	b = -b
+
+	for i := range f {
+		f[i] ^= b & (f[i] ^ g[i])
+	}
+}
+
+// FeNeg sets h to -f
+func FeNeg(h, f *FieldElement) {
+	for i := range h {
+		h[i] = -f[i]
+	}
+}
+
+func load3(in []byte) int64 {
+	r := int64(in[0])
+	r |= int64(in[1]) << 8
+	r |= int64(in[2]) << 16
+
+	return r
+}
+
+func load4(in []byte) int64 {
+	r := int64(in[0])
+	r |= int64(in[1]) << 8
+	r |= int64(in[2]) << 16
+	r |= int64(in[3]) << 24
+
+	return r
+}
+
+// FeFromBytes unpacks a 32 byte little endian value into dst, ignoring
+// the top bit
+func FeFromBytes(dst *FieldElement, src *[32]byte) {
+	feFromBytes(dst, src, 8388607)
+}
+
+// The mask is applied to the top 24 bits of the input. hash_to_ec needs
+// every bit of the hash to be taken into account, so it passes a mask
+// which keeps the top bit.
+func feFromBytes(dst *FieldElement, src *[32]byte, mask int64) {
+	h0 := load4(src[:])
+	h1 := load3(src[4:]) << 6
+	h2 := load3(src[7:]) << 5
+	h3 := load3(src[10:]) << 3
+	h4 := load3(src[13:]) << 2
+	h5 := load4(src[16:])
+	h6 := load3(src[20:]) << 7
+	h7 := load3(src[23:]) << 5
+	h8 := load3(src[26:]) << 4
+	h9 := (load3(src[29:]) & mask) << 2
+
+	feCombine(dst, h0, h1, h2, h3, h4, h5, h6, h7, h8, h9)
+}
+
+// FeToBytes packs h into its canonical 32 byte little endian encoding
+func FeToBytes(s *[32]byte, f *FieldElement) {
+	var carry [10]int32
+
+	h := *f
+
+	/* Preconditions:
+	   |h| bounded by 1.1*2^26,1.1*2^25,1.1*2^26,1.1*2^25,etc.
+
+	   Write p=2^255-19; q=floor(h/p).
+	   Basic claim: q = floor(2^(-255)(h + 19 2^(-25)h9 + 2^(-1))). */
+
+	q := (19*h[9] + (1 << 24)) >> 25
+	q = (h[0] + q) >> 26
+	q = (h[1] + q) >> 25
+	q = (h[2] + q) >> 26
+	q = (h[3] + q) >> 25
+	q = (h[4] + q) >> 26
+	q = (h[5] + q) >> 25
+	q = (h[6] + q) >> 26
+	q = (h[7] + q) >> 25
+	q = (h[8] + q) >> 26
+	q = (h[9] + q) >> 25
+
+	// Goal: Output h-(2^255-19)q, which is between 0 and 2^255-20
+	h[0] += 19 * q
+
+	// Goal: Output h-2^255 q, which is between 0 and 2^255-20
+	for i := 0; i < 9; i++ {
+		if i%2 == 0 {
+			carry[i] = h[i] >> 26
+			h[i+1] += carry[i]
+			h[i] -= carry[i] << 26
+		} else {
+			carry[i] = h[i] >> 25
+			h[i+1] += carry[i]
+			h[i] -= carry[i] << 25
+		}
+	}
+
+	carry[9] = h[9] >> 25
+	h[9] -= carry[9] << 25
+
+	s[0] = byte(h[0] >> 0)
+	s[1] = byte(h[0] >> 8)
+	s[2] = byte(h[0] >> 16)
+	s[3] = byte((h[0] >> 24) | (h[1] << 2))
+	s[4] = byte(h[1] >> 6)
+	s[5] = byte(h[1] >> 14)
+	s[6] = byte((h[1] >> 22) | (h[2] << 3))
+	s[7] = byte(h[2] >> 5)
+	s[8] = byte(h[2] >> 13)
+	s[9] = byte((h[2] >> 21) | (h[3] << 5))
+	s[10] = byte(h[3] >> 3)
+	s[11] = byte(h[3] >> 11)
+	s[12] = byte((h[3] >> 19) | (h[4] << 6))
+	s[13] = byte(h[4] >> 2)
+	s[14] = byte(h[4] >> 10)
+	s[15] = byte(h[4] >> 18)
+	s[16] = byte(h[5] >> 0)
+	s[17] = byte(h[5] >> 8)
+	s[18] = byte(h[5] >> 16)
+	s[19] = byte((h[5] >> 24) | (h[6] << 1))
+	s[20] = byte(h[6] >> 7)
+	s[21] = byte(h[6] >> 15)
+	s[22] = byte((h[6] >> 23) | (h[7] << 3))
+	s[23] = byte(h[7] >> 5)
+	s[24] = byte(h[7] >> 13)
+	s[25] = byte((h[7] >> 21) | (h[8] << 4))
+	s[26] = byte(h[8] >> 4)
+	s[27] = byte(h[8] >> 12)
+	s[28] = byte((h[8] >> 20) | (h[9] << 6))
+	s[29] = byte(h[9] >> 2)
+	s[30] = byte(h[9] >> 10)
+	s[31] = byte(h[9] >> 18)
+}
+
+// FeIsNegative returns 1 if f is negative (its canonical encoding is odd)
+func FeIsNegative(f *FieldElement) byte {
+	var s [32]byte
+
+	FeToBytes(&s, f)
+
+	return s[0] & 1
+}
+
+// FeIsNonZero returns 1 if f is not 0
+func FeIsNonZero(f *FieldElement) int32 {
+	var s [32]byte
+	var x byte
+
+	FeToBytes(&s, f)
+
+	for _, b := range s {
+		x |= b
+	}
+
+	x |= x >> 4
+	x |= x >> 2
+	x |= x >> 1
+
+	return int32(x & 1)
+}
+
+// Carries the wide limbs h0..h9 back down to 26/25 bit limbs
+func feCombine(h *FieldElement, h0, h1, h2, h3, h4, h5, h6, h7, h8, h9 int64) {
+	var c0, c1, c2, c3, c4, c5, c6, c7, c8, c9 int64
+
+	c0 = (h0 + (1 << 25)) >> 26
+	h1 += c0
+	h0 -= c0 << 26
+	c4 = (h4 + (1 << 25)) >> 26
+	h5 += c4
+	h4 -= c4 << 26
+
+	c1 = (h1 + (1 << 24)) >> 25
+	h2 += c1
+	h1 -= c1 << 25
+	c5 = (h5 + (1 << 24)) >> 25
+	h6 += c5
+	h5 -= c5 << 25
+
+	c2 = (h2 + (1 << 25)) >> 26
+	h3 += c2
+	h2 -= c2 << 26
+	c6 = (h6 + (1 << 25)) >> 26
+	h7 += c6
+	h6 -= c6 << 26
+
+	c3 = (h3 + (1 << 24)) >> 25
+	h4 += c3
+	h3 -= c3 << 25
+	c7 = (h7 + (1 << 24)) >> 25
+	h8 += c7
+	h7 -= c7 << 25
+
+	c4 = (h4 + (1 << 25)) >> 26
+	h5 += c4
+	h4 -= c4 << 26
+	c8 = (h8 + (1 << 25)) >> 26
+	h9 += c8
+	h8 -= c8 << 26
+
+	c9 = (h9 + (1 << 24)) >> 25
+	h0 += c9 * 19
+	h9 -= c9 << 25
+
+	c0 = (h0 + (1 << 25)) >> 26
+	h1 += c0
+	h0 -= c0 << 26
+
+	h[0] = int32(h0)
+	h[1] = int32(h1)
+	h[2] = int32(h2)
+	h[3] = int32(h3)
+	h[4] = int32(h4)
+	h[5] = int32(h5)
+	h[6] = int32(h6)
+	h[7] = int32(h7)
+	h[8] = int32(h8)
+	h[9] = int32(h9)
+}
+
+// FeMul sets h = f * g
+func FeMul(h, f, g *FieldElement) {
+	f0 := int64(f[0])
+	f1 := int64(f[1])
+	f2 := int64(f[2])
+	f3 := int64(f[3])
+	f4 := int64(f[4])
+	f5 := int64(f[5])
+	f6 := int64(f[6])
+	f7 := int64(f[7])
+	f8 := int64(f[8])
+	f9 := int64(f[9])
+
+	f1x2 := int64(2 * f[1])
+	f3x2 := int64(2 * f[3])
+	f5x2 := int64(2 * f[5])
+	f7x2 := int64(2 * f[7])
+	f9x2 := int64(2 * f[9])
+
+	g0 := int64(g[0])
+	g1 := int64(g[1])
+	g2 := int64(g[2])
+	g3 := int64(g[3])
+	g4 := int64(g[4])
+	g5 := int64(g[5])
+	g6 := int64(g[6])
+	g7 := int64(g[7])
+	g8 := int64(g[8])
+	g9 := int64(g[9])
+
+	g1x19 := int64(19 * g[1])
+	g2x19 := int64(19 * g[2])
+	g3x19 := int64(19 * g[3])
+	g4x19 := int64(19 * g[4])
+	g5x19 := int64(19 * g[5])
+	g6x19 := int64(19 * g[6])
+	g7x19 := int64(19 * g[7])
+	g8x19 := int64(19 * g[8])
+	g9x19 := int64(19 * g[9])
+
+	h0 := f0*g0 + f1x2*g9x19 + f2*g8x19 + f3x2*g7x19 + f4*g6x19 + f5x2*g5x19 + f6*g4x19 + f7x2*g3x19 + f8*g2x19 + f9x2*g1x19
+	h1 := f0*g1 + f1*g0 + f2*g9x19 + f3*g8x19 + f4*g7x19 + f5*g6x19 + f6*g5x19 + f7*g4x19 + f8*g3x19 + f9*g2x19
+	h2 := f0*g2 + f1x2*g1 + f2*g0 + f3x2*g9x19 + f4*g8x19 + f5x2*g7x19 + f6*g6x19 + f7x2*g5x19 + f8*g4x19 + f9x2*g3x19
+	h3 := f0*g3 + f1*g2 + f2*g1 + f3*g0 + f4*g9x19 + f5*g8x19 + f6*g7x19 + f7*g6x19 + f8*g5x19 + f9*g4x19
+	h4 := f0*g4 + f1x2*g3 + f2*g2 + f3x2*g1 + f4*g0 + f5x2*g9x19 + f6*g8x19 + f7x2*g7x19 + f8*g6x19 + f9x2*g5x19
+	h5 := f0*g5 + f1*g4 + f2*g3 + f3*g2 + f4*g1 + f5*g0 + f6*g9x19 + f7*g8x19 + f8*g7x19 + f9*g6x19
+	h6 := f0*g6 + f1x2*g5 + f2*g4 + f3x2*g3 + f4*g2 + f5x2*g1 + f6*g0 + f7x2*g9x19 + f8*g8x19 + f9x2*g7x19
+	h7 := f0*g7 + f1*g6 + f2*g5 + f3*g4 + f4*g3 + f5*g2 + f6*g1 + f7*g0 + f8*g9x19 + f9*g8x19
+	h8 := f0*g8 + f1x2*g7 + f2*g6 + f3x2*g5 + f4*g4 + f5x2*g3 + f6*g2 + f7x2*g1 + f8*g0 + f9x2*g9x19
+	h9 := f0*g9 + f1*g8 + f2*g7 + f3*g6 + f4*g5 + f5*g4 + f6*g3 + f7*g2 + f8*g1 + f9*g0
+
+	feCombine(h, h0, h1, h2, h3, h4, h5, h6, h7, h8, h9)
+}
+
+func feSquare(f *FieldElement) (h0, h1, h2, h3, h4, h5, h6, h7, h8, h9 int64) {
+	f0 := int64(f[0])
+	f1 := int64(f[1])
+	f2 := int64(f[2])
+	f3 := int64(f[3])
+	f4 := int64(f[4])
+	f5 := int64(f[5])
+	f6 := int64(f[6])
+	f7 := int64(f[7])
+	f8 := int64(f[8])
+	f9 := int64(f[9])
+
+	f0x2 := int64(2 * f[0])
+	f1x2 := int64(2 * f[1])
+	f2x2 := int64(2 * f[2])
+	f3x2 := int64(2 * f[3])
+	f4x2 := int64(2 * f[4])
+	f5x2 := int64(2 * f[5])
+	f6x2 := int64(2 * f[6])
+	f7x2 := int64(2 * f[7])
+
+	f5x38 := 38 * f5
+	f6x19 := 19 * f6
+	f7x38 := 38 * f7
+	f8x19 := 19 * f8
+	f9x38 := 38 * f9
+
+	h0 = f0*f0 + f1x2*f9x38 + f2x2*f8x19 + f3x2*f7x38 + f4x2*f6x19 + f5*f5x38
+	h1 = f0x2*f1 + f2*f9x38 + f3x2*f8x19 + f4*f7x38 + f5x2*f6x19
+	h2 = f0x2*f2 + f1x2*f1 + f3x2*f9x38 + f4x2*f8x19 + f5x2*f7x38 + f6*f6x19
+	h3 = f0x2*f3 + f1x2*f2 + f4*f9x38 + f5x2*f8x19 + f6*f7x38
+	h4 = f0x2*f4 + f1x2*f3x2 + f2*f2 + f5x2*f9x38 + f6x2*f8x19 + f7*f7x38
+	h5 = f0x2*f5 + f1x2*f4 + f2x2*f3 + f6*f9x38 + f7x2*f8x19
+	h6 = f0x2*f6 + f1x2*f5x2 + f2x2*f4 + f3x2*f3 + f7x2*f9x38 + f8*f8x19
+	h7 = f0x2*f7 + f1x2*f6 + f2x2*f5 + f3x2*f4 + f8*f9x38
+	h8 = f0x2*f8 + f1x2*f7x2 + f2x2*f6 + f3x2*f5x2 + f4*f4 + f9*f9x38
+	h9 = f0x2*f9 + f1x2*f8 + f2x2*f7 + f3x2*f6 + f4x2*f5
+
+	return
+}
+
+// FeSquare sets h = f * f
+func FeSquare(h, f *FieldElement) {
+	h0, h1, h2, h3, h4, h5, h6, h7, h8, h9 := feSquare(f)
+	feCombine(h, h0, h1, h2, h3, h4, h5, h6, h7, h8, h9)
+}
+
+// FeSquare2 sets h = 2 * f * f
+func FeSquare2(h, f *FieldElement) {
+	h0, h1, h2, h3, h4, h5, h6, h7, h8, h9 := feSquare(f)
+	feCombine(h, h0+h0, h1+h1, h2+h2, h3+h3, h4+h4, h5+h5, h6+h6, h7+h7, h8+h8, h9+h9)
+}
+
+// FeInvert sets out = z^(p-2) = 1/z
+func FeInvert(out, z *FieldElement) {
+	var t0, t1, t2, t3 FieldElement
+	var i int
+
+	FeSquare(&t0, z)        // 2^1
+	FeSquare(&t1, &t0)      // 2^2
+	for i = 1; i < 2; i++ { // 2^3
+		FeSquare(&t1, &t1)
+	}
+	FeMul(&t1, z, &t1)      // 2^3 + 2^0
+	FeMul(&t0, &t0, &t1)    // 2^3 + 2^1 + 2^0
+	FeSquare(&t2, &t0)      // 2^4 + 2^2 + 2^1
+	FeMul(&t1, &t1, &t2)    // 2^4 + 2^3 + 2^2 + 2^1 + 2^0
+	FeSquare(&t2, &t1)      // 5,4,3,2,1
+	for i = 1; i < 5; i++ { // 9,8,7,6,5
+		FeSquare(&t2, &t2)
+	}
+	FeMul(&t1, &t2, &t1)     // 9,8,7,6,5,4,3,2,1,0
+	FeSquare(&t2, &t1)       // 10..1
+	for i = 1; i < 10; i++ { // 19..10
+		FeSquare(&t2, &t2)
+	}
+	FeMul(&t2, &t2, &t1)     // 19..0
+	FeSquare(&t3, &t2)       // 20..1
+	for i = 1; i < 20; i++ { // 39..20
+		FeSquare(&t3, &t3)
+	}
+	FeMul(&t2, &t3, &t2)     // 39..0
+	FeSquare(&t2, &t2)       // 40..1
+	for i = 1; i < 10; i++ { // 49..10
+		FeSquare(&t2, &t2)
+	}
+	FeMul(&t1, &t2, &t1)     // 49..0
+	FeSquare(&t2, &t1)       // 50..1
+	for i = 1; i < 50; i++ { // 99..50
+		FeSquare(&t2, &t2)
+	}
+	FeMul(&t2, &t2, &t1)      // 99..0
+	FeSquare(&t3, &t2)        // 100..1
+	for i = 1; i < 100; i++ { // 199..100
+		FeSquare(&t3, &t3)
+	}
+	FeMul(&t2, &t3, &t2)     // 199..0
+	FeSquare(&t2, &t2)       // 200..1
+	for i = 1; i < 50; i++ { // 249..50
+		FeSquare(&t2, &t2)
+	}
+	FeMul(&t1, &t2, &t1)    // 249..0
+	FeSquare(&t1, &t1)      // 250..1
+	for i = 1; i < 5; i++ { // 254..5
+		FeSquare(&t1, &t1)
+	}
+	FeMul(out, &t1, &t0) // 254..5,3,1,0
+}
+
+// fePow22523 sets out = z^((p-5)/8) = z^(2^252-3)
+func fePow22523(out, z *FieldElement) {
+	var t0, t1, t2 FieldElement
+	var i int
+
+	FeSquare(&t0, z)     // 2
+	FeSquare(&t1, &t0)   // 4
+	FeSquare(&t1, &t1)   // 8
+	FeMul(&t1, z, &t1)   // 9
+	FeMul(&t0, &t0, &t1) // 11
+	FeSquare(&t0, &t0)   // 22
+	FeMul(&t0, &t1, &t0) // 2^5 - 2^0
+	FeSquare(&t1, &t0)
+	for i = 1; i < 5; i++ { // 2^10 - 2^5
+		FeSquare(&t1, &t1)
+	}
+	FeMul(&t0, &t1, &t0) // 2^10 - 2^0
+	FeSquare(&t1, &t0)
+	for i = 1; i < 10; i++ { // 2^20 - 2^10
+		FeSquare(&t1, &t1)
+	}
+	FeMul(&t1, &t1, &t0) // 2^20 - 2^0
+	FeSquare(&t2, &t1)
+	for i = 1; i < 20; i++ { // 2^40 - 2^20
+		FeSquare(&t2, &t2)
+	}
+	FeMul(&t1, &t2, &t1) // 2^40 - 2^0
+	FeSquare(&t1, &t1)
+	for i = 1; i < 10; i++ { // 2^50 - 2^10
+		FeSquare(&t1, &t1)
+	}
+	FeMul(&t0, &t1, &t0) // 2^50 - 2^0
+	FeSquare(&t1, &t0)
+	for i = 1; i < 50; i++ { // 2^100 - 2^50
+		FeSquare(&t1, &t1)
+	}
+	FeMul(&t1, &t1, &t0) // 2^100 - 2^0
+	FeSquare(&t2, &t1)
+	for i = 1; i < 100; i++ { // 2^200 - 2^100
+		FeSquare(&t2, &t2)
+	}
+	FeMul(&t1, &t2, &t1) // 2^200 - 2^0
+	FeSquare(&t1, &t1)
+	for i = 1; i < 50; i++ { // 2^250 - 2^50
+		FeSquare(&t1, &t1)
+	}
+	FeMul(&t0, &t1, &t0) // 2^250 - 2^0
+	FeSquare(&t0, &t0)   // 2^251 - 2^1
+	FeSquare(&t0, &t0)   // 2^252 - 2^2
+	FeMul(out, &t0, z)   // 2^252 - 3
+}
+
+// feDivPowM1 sets r = u * v^3 * (u * v^7)^((p-5)/8), which is
+// (u/v)^((p+3)/8) and used to take square roots of fractions
+func feDivPowM1(r, u, v *FieldElement) {
+	var v3, uv7 FieldElement
+
+	FeSquare(&v3, v)
+	FeMul(&v3, &v3, v) // v3 = v^3
+	FeSquare(&uv7, &v3)
+	FeMul(&uv7, &uv7, v)
+	FeMul(&uv7, &uv7, u) // uv7 = uv^7
+
+	fePow22523(&uv7, &uv7)
+
+	FeMul(&uv7, &uv7, &v3)
+	FeMul(r, &uv7, u) // u^(m+1)v^(-(m+1))
+}
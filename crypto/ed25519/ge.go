@@ -0,0 +1,608 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package ed25519
+
+/* Group elements are members of the elliptic curve -x^2 + y^2 = 1 + d * x^2 *
+   y^2 where d = -121665/121666.
+
+   Several representations are used:
+     ProjectiveGroupElement: (X:Y:Z) satisfying x=X/Z, y=Y/Z
+     ExtendedGroupElement: (X:Y:Z:T) satisfying x=X/Z, y=Y/Z, XY=ZT
+     CompletedGroupElement: ((X:Z),(Y:T)) satisfying x=X/Z, y=Y/T
+     PreComputedGroupElement: (y+x,y-x,2dxy)
+     CachedGroupElement: (Y+X,Y-X,Z,2dT) */
+
+// ProjectiveGroupElement is ge_p2
+type ProjectiveGroupElement struct {
+	X, Y, Z FieldElement
+}
+
+// ExtendedGroupElement is ge_p3
+type ExtendedGroupElement struct {
+	X, Y, Z, T FieldElement
+}
+
+// CompletedGroupElement is ge_p1p1
+type CompletedGroupElement struct {
+	X, Y, Z, T FieldElement
+}
+
+// PreComputedGroupElement is ge_precomp
+type PreComputedGroupElement struct {
+	yPlusX, yMinusX, xy2d FieldElement
+}
+
+// CachedGroupElement is ge_cached
+type CachedGroupElement struct {
+	yPlusX, yMinusX, Z, T2d FieldElement
+}
+
+// basePoint is the ed25519 base point B, decoded from its
+// encoding 0x5866666666...66 in init
+var basePoint ExtendedGroupElement
+
+// bi holds the odd multiples B, 3B, ..., 15B of the base point
+var bi [8]PreComputedGroupElement
+
+func init() {
+	var s [32]byte
+
+	s[0] = 0x58
+
+	for i := 1; i < 32; i++ {
+		s[i] = 0x66
+	}
+
+	if !basePoint.FromBytes(&s) {
+		panic("ed25519: failed to decode the base point")
+	}
+
+	var t CompletedGroupElement
+	var u, b2 ExtendedGroupElement
+	var c CachedGroupElement
+
+	basePoint.Double(&t)
+	t.ToExtended(&b2)
+	b2.ToCached(&c)
+
+	u = basePoint
+
+	for i := range bi {
+		u.toPreComputed(&bi[i])
+		GeAdd(&t, &u, &c)
+		t.ToExtended(&u)
+	}
+}
+
+// Zero sets p to the identity
+func (p *ProjectiveGroupElement) Zero() {
+	FeZero(&p.X)
+	FeOne(&p.Y)
+	FeOne(&p.Z)
+}
+
+// Double sets r = 2 * p
+func (p *ProjectiveGroupElement) Double(r *CompletedGroupElement) {
+	var t0 FieldElement
+
+	FeSquare(&r.X, &p.X)
+	FeSquare(&r.Z, &p.Y)
+	FeSquare2(&r.T, &p.Z)
+	FeAdd(&r.Y, &p.X, &p.Y)
+	FeSquare(&t0, &r.Y)
+	FeAdd(&r.Y, &r.Z, &r.X)
+	FeSub(&r.Z, &r.Z, &r.X)
+	FeSub(&r.X, &t0, &r.Y)
+	FeSub(&r.T, &r.T, &r.Z)
+}
+
+// ToBytes packs p into its 32 byte encoding
+func (p *ProjectiveGroupElement) ToBytes(s *[32]byte) {
+	var recip, x, y FieldElement
+
+	FeInvert(&recip, &p.Z)
+	FeMul(&x, &p.X, &recip)
+	FeMul(&y, &p.Y, &recip)
+	FeToBytes(s, &y)
+	s[31] ^= FeIsNegative(&x) << 7
+}
+
+// Zero sets p to the identity
+func (p *ExtendedGroupElement) Zero() {
+	FeZero(&p.X)
+	FeOne(&p.Y)
+	FeOne(&p.Z)
+	FeZero(&p.T)
+}
+
+// Double sets r = 2 * p
+func (p *ExtendedGroupElement) Double(r *CompletedGroupElement) {
+	var q ProjectiveGroupElement
+
+	p.ToProjective(&q)
+	q.Double(r)
+}
+
+// ToCached converts p into the form used as the right hand
+// side of GeAdd and GeSub
+func (p *ExtendedGroupElement) ToCached(r *CachedGroupElement) {
+	FeAdd(&r.yPlusX, &p.Y, &p.X)
+	FeSub(&r.yMinusX, &p.Y, &p.X)
+	FeCopy(&r.Z, &p.Z)
+	FeMul(&r.T2d, &p.T, &feD2)
+}
+
+// ToProjective drops the T coordinate of p
+func (p *ExtendedGroupElement) ToProjective(r *ProjectiveGroupElement) {
+	FeCopy(&r.X, &p.X)
+	FeCopy(&r.Y, &p.Y)
+	FeCopy(&r.Z, &p.Z)
+}
+
+// ToBytes packs p into its 32 byte encoding
+func (p *ExtendedGroupElement) ToBytes(s *[32]byte) {
+	var q ProjectiveGroupElement
+
+	p.ToProjective(&q)
+	q.ToBytes(s)
+}
+
+// Converts p to precomputed form, this needs an inversion so
+// is only used when building tables
+func (p *ExtendedGroupElement) toPreComputed(r *PreComputedGroupElement) {
+	var recip, x, y FieldElement
+
+	FeInvert(&recip, &p.Z)
+	FeMul(&x, &p.X, &recip)
+	FeMul(&y, &p.Y, &recip)
+	FeAdd(&r.yPlusX, &y, &x)
+	FeSub(&r.yMinusX, &y, &x)
+	FeMul(&r.xy2d, &x, &y)
+	FeMul(&r.xy2d, &r.xy2d, &feD2)
+}
+
+// FromBytes unpacks a 32 byte encoded point into p. It returns false
+// if s is not the canonical encoding of a point on the curve, this is
+// the check CryptoNote uses to validate public keys.
+func (p *ExtendedGroupElement) FromBytes(s *[32]byte) bool {
+	var u, v, vxx, check FieldElement
+
+	/* Validate the number to be canonical, y must be less than p */
+	if s[31]&0x7f == 0x7f && s[0] >= 0xed {
+		canonical := false
+
+		for i := 1; i < 31; i++ {
+			if s[i] != 0xff {
+				canonical = true
+				break
+			}
+		}
+
+		if !canonical {
+			return false
+		}
+	}
+
+	FeFromBytes(&p.Y, s)
+	FeOne(&p.Z)
+	FeSquare(&u, &p.Y)
+	FeMul(&v, &u, &feD)
+	FeSub(&u, &u, &p.Z) // u = y^2-1
+	FeAdd(&v, &v, &p.Z) // v = dy^2+1
+
+	feDivPowM1(&p.X, &u, &v) // x = uv^3(uv^7)^((q-5)/8)
+
+	FeSquare(&vxx, &p.X)
+	FeMul(&vxx, &vxx, &v)
+	FeSub(&check, &vxx, &u) // vx^2-u
+
+	if FeIsNonZero(&check) == 1 {
+		FeAdd(&check, &vxx, &u) // vx^2+u
+
+		if FeIsNonZero(&check) == 1 {
+			return false
+		}
+
+		FeMul(&p.X, &p.X, &feSqrtm1)
+	}
+
+	if FeIsNegative(&p.X) != s[31]>>7 {
+		/* If x = 0, the sign must be positive */
+		if FeIsNonZero(&p.X) == 0 {
+			return false
+		}
+
+		FeNeg(&p.X, &p.X)
+	}
+
+	FeMul(&p.T, &p.X, &p.Y)
+
+	return true
+}
+
+// ToProjective converts p to its projective form
+func (p *CompletedGroupElement) ToProjective(r *ProjectiveGroupElement) {
+	FeMul(&r.X, &p.X, &p.T)
+	FeMul(&r.Y, &p.Y, &p.Z)
+	FeMul(&r.Z, &p.Z, &p.T)
+}
+
+// ToExtended converts p to its extended form
+func (p *CompletedGroupElement) ToExtended(r *ExtendedGroupElement) {
+	FeMul(&r.X, &p.X, &p.T)
+	FeMul(&r.Y, &p.Y, &p.Z)
+	FeMul(&r.Z, &p.Z, &p.T)
+	FeMul(&r.T, &p.X, &p.Y)
+}
+
+// Zero sets p to the identity
+func (p *CachedGroupElement) Zero() {
+	FeOne(&p.yPlusX)
+	FeOne(&p.yMinusX)
+	FeOne(&p.Z)
+	FeZero(&p.T2d)
+}
+
+// Replaces p with u if b == 1, without branching on b
+func (p *CachedGroupElement) cmov(u *CachedGroupElement, b int32) {
+	FeCMove(&p.yPlusX, &u.yPlusX, b)
+	FeCMove(&p.yMinusX, &u.yMinusX, b)
+	FeCMove(&p.Z, &u.Z, b)
+	FeCMove(&p.T2d, &u.T2d, b)
+}
+
+// GeAdd sets r = p + q
+func GeAdd(r *CompletedGroupElement, p *ExtendedGroupElement, q *CachedGroupElement) {
+	var t0 FieldElement
+
+	FeAdd(&r.X, &p.Y, &p.X)
+	FeSub(&r.Y, &p.Y, &p.X)
+	FeMul(&r.Z, &r.X, &q.yPlusX)
+	FeMul(&r.Y, &r.Y, &q.yMinusX)
+	FeMul(&r.T, &q.T2d, &p.T)
+	FeMul(&r.X, &p.Z, &q.Z)
+	FeAdd(&t0, &r.X, &r.X)
+	FeSub(&r.X, &r.Z, &r.Y)
+	FeAdd(&r.Y, &r.Z, &r.Y)
+	FeAdd(&r.Z, &t0, &r.T)
+	FeSub(&r.T, &t0, &r.T)
+}
+
+// GeSub sets r = p - q
+func GeSub(r *CompletedGroupElement, p *ExtendedGroupElement, q *CachedGroupElement) {
+	var t0 FieldElement
+
+	FeAdd(&r.X, &p.Y, &p.X)
+	FeSub(&r.Y, &p.Y, &p.X)
+	FeMul(&r.Z, &r.X, &q.yMinusX)
+	FeMul(&r.Y, &r.Y, &q.yPlusX)
+	FeMul(&r.T, &q.T2d, &p.T)
+	FeMul(&r.X, &p.Z, &q.Z)
+	FeAdd(&t0, &r.X, &r.X)
+	FeSub(&r.X, &r.Z, &r.Y)
+	FeAdd(&r.Y, &r.Z, &r.Y)
+	FeSub(&r.Z, &t0, &r.T)
+	FeAdd(&r.T, &t0, &r.T)
+}
+
+// Sets r = p + q, where q is in precomputed form
+func geMixedAdd(r *CompletedGroupElement, p *ExtendedGroupElement, q *PreComputedGroupElement) {
+	var t0 FieldElement
+
+	FeAdd(&r.X, &p.Y, &p.X)
+	FeSub(&r.Y, &p.Y, &p.X)
+	FeMul(&r.Z, &r.X, &q.yPlusX)
+	FeMul(&r.Y, &r.Y, &q.yMinusX)
+	FeMul(&r.T, &q.xy2d, &p.T)
+	FeAdd(&t0, &p.Z, &p.Z)
+	FeSub(&r.X, &r.Z, &r.Y)
+	FeAdd(&r.Y, &r.Z, &r.Y)
+	FeAdd(&r.Z, &t0, &r.T)
+	FeSub(&r.T, &t0, &r.T)
+}
+
+// Sets r = p - q, where q is in precomputed form
+func geMixedSub(r *CompletedGroupElement, p *ExtendedGroupElement, q *PreComputedGroupElement) {
+	var t0 FieldElement
+
+	FeAdd(&r.X, &p.Y, &p.X)
+	FeSub(&r.Y, &p.Y, &p.X)
+	FeMul(&r.Z, &r.X, &q.yMinusX)
+	FeMul(&r.Y, &r.Y, &q.yPlusX)
+	FeMul(&r.T, &q.xy2d, &p.T)
+	FeAdd(&t0, &p.Z, &p.Z)
+	FeSub(&r.X, &r.Z, &r.Y)
+	FeAdd(&r.Y, &r.Z, &r.Y)
+	FeSub(&r.Z, &t0, &r.T)
+	FeAdd(&r.T, &t0, &r.T)
+}
+
+// Returns 1 if b == c, 0 otherwise, without branching
+func equal(b, c int32) int32 {
+	x := uint32(b ^ c)
+	x--
+
+	return int32(x >> 31)
+}
+
+// Returns 1 if b is negative, 0 otherwise, without branching
+func negative(b int32) int32 {
+	return (b >> 31) & 1
+}
+
+// GeScalarMult sets r = a * A, where a = a[0]+256*a[1]+...+256^31 a[31].
+// a[31] must be at most 127. The table lookups are done in constant
+// time so a may be secret.
+func GeScalarMult(r *ExtendedGroupElement, a *[32]byte, A *ExtendedGroupElement) {
+	var e [64]int32
+	var ai [8]CachedGroupElement // 1 * A, 2 * A, ..., 8 * A
+	var t CompletedGroupElement
+	var u ExtendedGroupElement
+	var p ProjectiveGroupElement
+
+	var carry, carry2 int32
+
+	for i := 0; i < 31; i++ {
+		carry += int32(a[i])             // 0..256
+		carry2 = (carry + 8) >> 4        // 0..16
+		e[2*i] = carry - (carry2 << 4)   // -8..7
+		carry = (carry2 + 8) >> 4        // 0..1
+		e[2*i+1] = carry2 - (carry << 4) // -8..7
+	}
+
+	carry += int32(a[31])         // 0..128
+	carry2 = (carry + 8) >> 4     // 0..8
+	e[62] = carry - (carry2 << 4) // -8..7
+	e[63] = carry2                // 0..8
+
+	A.ToCached(&ai[0])
+
+	for i := 0; i < 7; i++ {
+		GeAdd(&t, A, &ai[i])
+		t.ToExtended(&u)
+		u.ToCached(&ai[i+1])
+	}
+
+	p.Zero()
+
+	for i := 63; i >= 0; i-- {
+		var cur, minusCur CachedGroupElement
+
+		b := e[i]
+		bNegative := negative(b)
+		bAbs := b - (((-bNegative) & b) << 1)
+
+		p.Double(&t)
+		t.ToProjective(&p)
+		p.Double(&t)
+		t.ToProjective(&p)
+		p.Double(&t)
+		t.ToProjective(&p)
+		p.Double(&t)
+		t.ToExtended(&u)
+
+		cur.Zero()
+
+		for j := int32(0); j < 8; j++ {
+			cur.cmov(&ai[j], equal(bAbs, j+1))
+		}
+
+		FeCopy(&minusCur.yPlusX, &cur.yMinusX)
+		FeCopy(&minusCur.yMinusX, &cur.yPlusX)
+		FeCopy(&minusCur.Z, &cur.Z)
+		FeNeg(&minusCur.T2d, &cur.T2d)
+
+		cur.cmov(&minusCur, bNegative)
+
+		GeAdd(&t, &u, &cur)
+
+		if i == 0 {
+			t.ToExtended(r)
+		} else {
+			t.ToProjective(&p)
+		}
+	}
+}
+
+// GeMul8 sets r = 8 * t
+func GeMul8(r *CompletedGroupElement, t *ProjectiveGroupElement) {
+	var u ProjectiveGroupElement
+
+	t.Double(r)
+	r.ToProjective(&u)
+	u.Double(r)
+	r.ToProjective(&u)
+	u.Double(r)
+}
+
+// GeFromFeFromBytesVartime maps the 32 byte value s, usually a hash, to a
+// point on the curve. This is the Elligator style map used by CryptoNote's
+// hash_to_ec. The result still has to be multiplied by 8 to be in the
+// prime order subgroup.
+func GeFromFeFromBytesVartime(r *ProjectiveGroupElement, s *[32]byte) {
+	var u, v, w, x, y, z FieldElement
+	var sign byte
+
+	feFromBytes(&u, s, 16777215)
+
+	FeSquare2(&v, &u) // 2 * u^2
+	FeOne(&w)
+	FeAdd(&w, &v, &w)        // w = 2 * u^2 + 1
+	FeSquare(&x, &w)         // w^2
+	FeMul(&y, &feMa2, &v)    // -2 * A^2 * u^2
+	FeAdd(&x, &x, &y)        // x = w^2 - 2 * A^2 * u^2
+	feDivPowM1(&r.X, &w, &x) // (w / x)^(m + 1)
+	FeSquare(&y, &r.X)
+	FeMul(&x, &y, &x)
+	FeSub(&y, &w, &x)
+	FeCopy(&z, &feMa)
+
+	if FeIsNonZero(&y) != 0 {
+		FeAdd(&y, &w, &x)
+
+		if FeIsNonZero(&y) != 0 {
+			FeMul(&x, &x, &feSqrtm1)
+			FeSub(&y, &w, &x)
+
+			if FeIsNonZero(&y) != 0 {
+				FeMul(&r.X, &r.X, &feFfffb3)
+			} else {
+				FeMul(&r.X, &r.X, &feFfffb4)
+			}
+
+			// r.X = sqrt(A * (A + 2) * w / x)
+			// z = -A
+			sign = 1
+		} else {
+			FeMul(&r.X, &r.X, &feFfffb1)
+		}
+	} else {
+		FeMul(&r.X, &r.X, &feFfffb2)
+	}
+
+	if sign == 0 {
+		FeMul(&r.X, &r.X, &u) // u * sqrt(2 * A * (A + 2) * w / x)
+		FeMul(&z, &z, &v)     // -2 * A * u^2
+	}
+
+	if FeIsNegative(&r.X) != sign {
+		FeNeg(&r.X, &r.X)
+	}
+
+	FeAdd(&r.Z, &z, &w)
+	FeSub(&r.Y, &z, &w)
+	FeMul(&r.X, &r.X, &r.Z)
+}
+
+// slide recodes a into a signed digit form where every non zero digit is
+// odd and in -15..15, with at least 4 zero digits between them
+func slide(r *[256]int8, a *[32]byte) {
+	for i := range r {
+		r[i] = int8(1 & (a[i>>3] >> uint(i&7)))
+	}
+
+	for i := range r {
+		if r[i] == 0 {
+			continue
+		}
+
+		for b := 1; b <= 6 && i+b < 256; b++ {
+			if r[i+b] == 0 {
+				continue
+			}
+
+			if r[i]+(r[i+b]<<uint(b)) <= 15 {
+				r[i] += r[i+b] << uint(b)
+				r[i+b] = 0
+			} else if r[i]-(r[i+b]<<uint(b)) >= -15 {
+				r[i] -= r[i+b] << uint(b)
+
+				for k := i + b; k < 256; k++ {
+					if r[k] == 0 {
+						r[k] = 1
+						break
+					}
+
+					r[k] = 0
+				}
+			} else {
+				break
+			}
+		}
+	}
+}
+
+// DsmPrecomp holds the odd multiples A, 3A, ..., 15A of a point, for
+// use as the fixed point of GeDoubleScalarMultPrecompVartime
+type DsmPrecomp [8]CachedGroupElement
+
+// GeDsmPrecomp calculates the odd multiples of s into r
+func GeDsmPrecomp(r *DsmPrecomp, s *ExtendedGroupElement) {
+	var t CompletedGroupElement
+	var s2, u ExtendedGroupElement
+
+	s.ToCached(&r[0])
+	s.Double(&t)
+	t.ToExtended(&s2)
+
+	for i := 0; i < 7; i++ {
+		GeAdd(&t, &s2, &r[i])
+		t.ToExtended(&u)
+		u.ToCached(&r[i+1])
+	}
+}
+
+// GeDoubleScalarMultBaseVartime sets r = a * A + b * B, where B is the
+// ed25519 base point. This is not constant time, so a and b must be public.
+func GeDoubleScalarMultBaseVartime(r *ProjectiveGroupElement, a *[32]byte, A *ExtendedGroupElement, b *[32]byte) {
+	geDoubleScalarMultVartime(r, a, A, b, nil)
+}
+
+// GeDoubleScalarMultPrecompVartime sets r = a * A + b * B, where bi holds
+// the precomputed multiples of B. This is not constant time, so a and b
+// must be public.
+func GeDoubleScalarMultPrecompVartime(r *ProjectiveGroupElement, a *[32]byte, A *ExtendedGroupElement, b *[32]byte, bi *DsmPrecomp) {
+	geDoubleScalarMultVartime(r, a, A, b, bi)
+}
+
+// Sets r = a * A + b * B, using the base point table if bPre is nil
+func geDoubleScalarMultVartime(r *ProjectiveGroupElement, a *[32]byte, A *ExtendedGroupElement, b *[32]byte, bPre *DsmPrecomp) {
+	var aSlide, bSlide [256]int8
+	var ai DsmPrecomp
+	var t CompletedGroupElement
+	var u ExtendedGroupElement
+	var i int
+
+	slide(&aSlide, a)
+	slide(&bSlide, b)
+
+	GeDsmPrecomp(&ai, A)
+
+	r.Zero()
+
+	for i = 255; i >= 0; i-- {
+		if aSlide[i] != 0 || bSlide[i] != 0 {
+			break
+		}
+	}
+
+	for ; i >= 0; i-- {
+		r.Double(&t)
+
+		if aSlide[i] > 0 {
+			t.ToExtended(&u)
+			GeAdd(&t, &u, &ai[aSlide[i]/2])
+		} else if aSlide[i] < 0 {
+			t.ToExtended(&u)
+			GeSub(&t, &u, &ai[(-aSlide[i])/2])
+		}
+
+		if bPre == nil {
+			if bSlide[i] > 0 {
+				t.ToExtended(&u)
+				geMixedAdd(&t, &u, &bi[bSlide[i]/2])
+			} else if bSlide[i] < 0 {
+				t.ToExtended(&u)
+				geMixedSub(&t, &u, &bi[(-bSlide[i])/2])
+			}
+		} else {
+			if bSlide[i] > 0 {
+				t.ToExtended(&u)
+				GeAdd(&t, &u, &bPre[bSlide[i]/2])
+			} else if bSlide[i] < 0 {
+				t.ToExtended(&u)
+				GeSub(&t, &u, &bPre[(-bSlide[i])/2])
+			}
+		}
+
+		t.ToProjective(r)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package ed25519
+
+import "errors"
+
+// The Montgomery form of the curve, v^2 = u^3 + A u^2 + u with A = 486662,
+// is birationally equivalent to the twisted Edwards form used everywhere
+// else in this package, u = (1 + y) / (1 - y) and y = (u - 1) / (u + 1).
+// X25519 (RFC 7748) works on the u coordinate alone.
+
+// ErrLowOrderPoint is returned when a Diffie-Hellman exchange results in
+// the all zero value, because the peer sent a point of small order
+var ErrLowOrderPoint = errors.New("ed25519: low order point")
+
+// ErrInvalidPoint is returned when a point can't be decoded or converted
+var ErrInvalidPoint = errors.New("ed25519: invalid point")
+
+// a24 is (A - 2) / 4
+var a24 = FieldElement{121665, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+// Swaps f and g if b == 1, without branching on b
+func feCSwap(f, g *FieldElement, b int32) {
+	b = -b
+
+	for i := range f {
+		x := b & (f[i] ^ g[i])
+		f[i] ^= x
+		g[i] ^= x
+	}
+}
+
+// MontgomeryScalarMult sets dst to the u coordinate of scalar * u, using
+// the Montgomery ladder. Every bit of scalar is used, no clamping is done.
+func MontgomeryScalarMult(dst, scalar, u *[32]byte) {
+	var x1, x2, z2, x3, z3 FieldElement
+	var a, aa, b, bb, e, c, d, da, cb FieldElement
+
+	FeFromBytes(&x1, u)
+	FeOne(&x2)
+	FeZero(&z2)
+	FeCopy(&x3, &x1)
+	FeOne(&z3)
+
+	var swap int32
+
+	for pos := 255; pos >= 0; pos-- {
+		bit := int32(scalar[pos/8]>>uint(pos&7)) & 1
+
+		swap ^= bit
+		feCSwap(&x2, &x3, swap)
+		feCSwap(&z2, &z3, swap)
+		swap = bit
+
+		FeAdd(&a, &x2, &z2)
+		FeSquare(&aa, &a)
+		FeSub(&b, &x2, &z2)
+		FeSquare(&bb, &b)
+		FeSub(&e, &aa, &bb)
+		FeAdd(&c, &x3, &z3)
+		FeSub(&d, &x3, &z3)
+		FeMul(&da, &d, &a)
+		FeMul(&cb, &c, &b)
+
+		FeAdd(&x3, &da, &cb)
+		FeSquare(&x3, &x3)
+		FeSub(&z3, &da, &cb)
+		FeSquare(&z3, &z3)
+		FeMul(&z3, &z3, &x1)
+
+		FeMul(&x2, &aa, &bb)
+		FeMul(&z2, &a24, &e)
+		FeAdd(&z2, &z2, &aa)
+		FeMul(&z2, &z2, &e)
+	}
+
+	feCSwap(&x2, &x3, swap)
+	feCSwap(&z2, &z3, swap)
+
+	FeInvert(&z2, &z2)
+	FeMul(&x2, &x2, &z2)
+	FeToBytes(dst, &x2)
+}
+
+// X25519 performs the RFC 7748 function, the scalar is clamped before
+// use. Using basepoint 9 gives the public key of scalar.
+func X25519(scalar, point []byte) ([]byte, error) {
+	var k, u, out [32]byte
+
+	if len(scalar) != 32 || len(point) != 32 {
+		return nil, errors.New("ed25519: X25519 inputs must be 32 bytes")
+	}
+
+	copy(k[:], scalar)
+	copy(u[:], point)
+
+	k[0] &= 248
+	k[31] &= 127
+	k[31] |= 64
+
+	MontgomeryScalarMult(&out, &k, &u)
+
+	if out == [32]byte{} {
+		return nil, ErrLowOrderPoint
+	}
+
+	return out[:], nil
+}
+
+// EdwardsToMontgomery converts an encoded Edwards point to its Montgomery
+// u coordinate, u = (1 + y) / (1 - y)
+func EdwardsToMontgomery(dst, edwards *[32]byte) error {
+	var p ExtendedGroupElement
+	var n, d FieldElement
+
+	if !p.FromBytes(edwards) {
+		return ErrInvalidPoint
+	}
+
+	FeAdd(&n, &p.Z, &p.Y)
+	FeSub(&d, &p.Z, &p.Y)
+	FeInvert(&d, &d)
+	FeMul(&n, &n, &d)
+	FeToBytes(dst, &n)
+
+	return nil
+}
+
+// MontgomeryToEdwards converts a Montgomery u coordinate to an encoded
+// Edwards point, y = (u - 1) / (u + 1). The u coordinate doesn't carry the
+// sign of x, so it is given by sign, 0 or 1.
+func MontgomeryToEdwards(dst, u *[32]byte, sign byte) error {
+	var x, one, n, d FieldElement
+	var p ExtendedGroupElement
+
+	FeFromBytes(&x, u)
+	FeOne(&one)
+
+	FeAdd(&d, &x, &one)
+
+	if FeIsNonZero(&d) == 0 {
+		return ErrInvalidPoint
+	}
+
+	FeSub(&n, &x, &one)
+	FeInvert(&d, &d)
+	FeMul(&n, &n, &d)
+	FeToBytes(dst, &n)
+
+	dst[31] |= (sign & 1) << 7
+
+	if !p.FromBytes(dst) {
+		return ErrInvalidPoint
+	}
+
+	return nil
+}
+
+// SharedKey calculates a Diffie-Hellman shared secret between the CryptoNote
+// secret key secret and the encoded Edwards public key of the other party,
+// such as their public view key. It returns the u coordinate of
+// 8 * secret * public, so both parties arrive at the same value and points
+// of small order are rejected.
+func SharedKey(secret, public *[32]byte) ([32]byte, error) {
+	var u, scalar, out [32]byte
+
+	if !ScCheck(secret) {
+		return out, errors.New("ed25519: secret key is not a reduced scalar")
+	}
+
+	if err := EdwardsToMontgomery(&u, public); err != nil {
+		return out, err
+	}
+
+	// 8 * secret, as an integer, fits as secret is less than 2^253
+	var carry byte
+
+	for i := range secret {
+		scalar[i] = secret[i]<<3 | carry
+		carry = secret[i] >> 5
+	}
+
+	MontgomeryScalarMult(&out, &scalar, &u)
+
+	if out == [32]byte{} {
+		return out, ErrLowOrderPoint
+	}
+
+	return out, nil
+}
@@ -0,0 +1,121 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package ed25519
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+)
+
+// x25519Tests are the X25519 test vectors of RFC 7748, section 5.2, and
+// the key pairs and shared secret of section 6.1
+var x25519Tests = []struct {
+	scalar string
+	point  string
+	result string
+}{
+	{
+		"a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4",
+		"e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c",
+		"c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552",
+	},
+	{
+		"77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a",
+		"0900000000000000000000000000000000000000000000000000000000000000",
+		"8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a",
+	},
+	{
+		"5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb",
+		"0900000000000000000000000000000000000000000000000000000000000000",
+		"de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f",
+	},
+	{
+		"77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a",
+		"de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f",
+		"4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742",
+	},
+	{
+		"5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb",
+		"8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a",
+		"4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742",
+	},
+}
+
+func TestX25519Vectors(t *testing.T) {
+	for _, test := range x25519Tests {
+		res, err := X25519(unhex(t, test.scalar), unhex(t, test.point))
+
+		if err != nil {
+			t.Errorf("X25519(%s, %s): %v", test.scalar, test.point, err)
+			continue
+		}
+
+		if !bytes.Equal(res, unhex(t, test.result)) {
+			t.Errorf("X25519(%s, %s) = %x, want %s", test.scalar, test.point, res, test.result)
+		}
+	}
+}
+
+func TestX25519LowOrder(t *testing.T) {
+	scalar := unhex(t, "a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4")
+
+	// u = 0 and u = 1 are points of small order
+	for _, point := range [][]byte{make([]byte, 32), append([]byte{1}, make([]byte, 31)...)} {
+		if _, err := X25519(scalar, point); err != ErrLowOrderPoint {
+			t.Errorf("X25519 with u = %x: err = %v, want %v", point[0], err, ErrLowOrderPoint)
+		}
+	}
+}
+
+// TestECDH checks public keys and shared secrets match crypto/ecdh
+func TestECDH(t *testing.T) {
+	basepoint := append([]byte{9}, make([]byte, 31)...)
+
+	for i := 0; i < 64; i++ {
+		a, err := ecdh.X25519().GenerateKey(rand.Reader)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := ecdh.X25519().GenerateKey(rand.Reader)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		public, err := X25519(a.Bytes(), basepoint)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(public, a.PublicKey().Bytes()) {
+			t.Fatalf("public key of %x is %x, want %x", a.Bytes(), public, a.PublicKey().Bytes())
+		}
+
+		shared, err := X25519(a.Bytes(), b.PublicKey().Bytes())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := a.ECDH(b.PublicKey())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(shared, want) {
+			t.Fatalf("shared secret of %x and %x differs from crypto/ecdh", a.Bytes(), b.PublicKey().Bytes())
+		}
+	}
+}
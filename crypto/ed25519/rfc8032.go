@@ -0,0 +1,198 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package ed25519
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"io"
+	"strconv"
+)
+
+// Standard ed25519 signatures, as described in RFC 8032. Keys and signatures
+// are interchangeable with the crypto/ed25519 package of the Go standard
+// library. These are not the CryptoNote signatures used by transactions.
+
+const (
+	// PublicKeySize is the size, in bytes, of public keys
+	PublicKeySize = 32
+	// PrivateKeySize is the size, in bytes, of private keys
+	PrivateKeySize = 64
+	// SignatureSize is the size, in bytes, of signatures
+	SignatureSize = 64
+	// SeedSize is the size, in bytes, of private key seeds
+	SeedSize = 32
+)
+
+// PublicKey is an RFC 8032 public key
+type PublicKey []byte
+
+// PrivateKey is an RFC 8032 private key, the seed followed by the
+// public key
+type PrivateKey []byte
+
+// Public returns the public key of priv
+func (priv PrivateKey) Public() PublicKey {
+	publicKey := make([]byte, PublicKeySize)
+
+	copy(publicKey, priv[SeedSize:])
+
+	return publicKey
+}
+
+// Seed returns the seed priv was created from
+func (priv PrivateKey) Seed() []byte {
+	seed := make([]byte, SeedSize)
+
+	copy(seed, priv[:SeedSize])
+
+	return seed
+}
+
+// GenerateKey generates a key pair using entropy from random, or
+// crypto/rand if random is nil
+func GenerateKey(random io.Reader) (PublicKey, PrivateKey, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+
+	seed := make([]byte, SeedSize)
+
+	if _, err := io.ReadFull(random, seed); err != nil {
+		return nil, nil, err
+	}
+
+	privateKey := NewKeyFromSeed(seed)
+
+	return privateKey.Public(), privateKey, nil
+}
+
+// NewKeyFromSeed calculates the private key from a seed. It panics if
+// len(seed) is not SeedSize.
+func NewKeyFromSeed(seed []byte) PrivateKey {
+	var a ExtendedGroupElement
+	var publicKey [32]byte
+
+	if l := len(seed); l != SeedSize {
+		panic("ed25519: bad seed length: " + strconv.Itoa(l))
+	}
+
+	scalar, _ := expandSeed(seed)
+
+	GeScalarMultBase(&a, &scalar)
+
+	a.ToBytes(&publicKey)
+
+	privateKey := make([]byte, PrivateKeySize)
+
+	copy(privateKey, seed)
+	copy(privateKey[SeedSize:], publicKey[:])
+
+	return privateKey
+}
+
+// expandSeed hashes the seed with SHA-512, the first half is clamped into
+// the secret scalar and the second half is the nonce prefix
+func expandSeed(seed []byte) ([32]byte, []byte) {
+	var scalar [32]byte
+
+	digest := sha512.Sum512(seed)
+
+	copy(scalar[:], digest[:32])
+
+	scalar[0] &= 248
+	scalar[31] &= 127
+	scalar[31] |= 64
+
+	return scalar, digest[32:]
+}
+
+// hashToScalar reduces SHA-512(parts...) modulo the group order
+func hashToScalar(parts ...[]byte) [32]byte {
+	var digest [64]byte
+	var res [32]byte
+
+	h := sha512.New()
+
+	for _, part := range parts {
+		h.Write(part)
+	}
+
+	h.Sum(digest[:0])
+
+	ScReduce(&res, &digest)
+
+	return res
+}
+
+// Sign signs message with privateKey and returns the signature. It
+// panics if len(privateKey) is not PrivateKeySize.
+func Sign(privateKey PrivateKey, message []byte) []byte {
+	var R ExtendedGroupElement
+	var encodedR, s [32]byte
+
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+
+	a, prefix := expandSeed(privateKey[:SeedSize])
+
+	r := hashToScalar(prefix, message)
+
+	GeScalarMultBase(&R, &r)
+
+	R.ToBytes(&encodedR)
+
+	k := hashToScalar(encodedR[:], privateKey[SeedSize:], message)
+
+	ScMulAdd(&s, &k, &a, &r)
+
+	signature := make([]byte, SignatureSize)
+
+	copy(signature, encodedR[:])
+	copy(signature[32:], s[:])
+
+	return signature
+}
+
+// Verify returns true if sig is a valid signature of message by
+// publicKey
+func Verify(publicKey PublicKey, message, sig []byte) bool {
+	var A ExtendedGroupElement
+	var R ProjectiveGroupElement
+	var encodedA, encodedR, s [32]byte
+
+	if len(publicKey) != PublicKeySize || len(sig) != SignatureSize {
+		return false
+	}
+
+	copy(encodedA[:], publicKey)
+	copy(s[:], sig[32:])
+
+	if !ScCheck(&s) {
+		return false
+	}
+
+	if !A.FromBytes(&encodedA) {
+		return false
+	}
+
+	// R = s * B - k * A, checked against the R in the signature
+	FeNeg(&A.X, &A.X)
+	FeNeg(&A.T, &A.T)
+
+	k := hashToScalar(sig[:32], publicKey, message)
+
+	GeDoubleScalarMultBaseVartime(&R, &k, &A, &s)
+
+	R.ToBytes(&encodedR)
+
+	return bytes.Equal(encodedR[:], sig[:32])
+}
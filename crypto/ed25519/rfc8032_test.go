@@ -0,0 +1,129 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package ed25519
+
+import (
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+)
+
+// unhex decodes s, failing the test if it isn't hex
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+
+	if err != nil {
+		t.Fatalf("bad hex %q", s)
+	}
+
+	return b
+}
+
+// rfc8032Tests are the Ed25519 test vectors of RFC 8032, section 7.1
+var rfc8032Tests = []struct {
+	name      string
+	seed      string
+	public    string
+	message   string
+	signature string
+}{
+	{
+		"TEST 1",
+		"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		"",
+		"e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+	},
+	{
+		"TEST 2",
+		"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+		"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+		"72",
+		"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+	},
+	{
+		"TEST 3",
+		"c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+		"fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+		"af82",
+		"6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a",
+	},
+	{
+		"TEST SHA(abc)",
+		"833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42",
+		"ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf",
+		"ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+		"dc2a4459e7369633a52b1bf277839a00201009a3efbf3ecb69bea2186c26b58909351fc9ac90b3ecfdfbc7c66431e0303dca179c138ac17ad9bef1177331a704",
+	},
+}
+
+func TestRFC8032Vectors(t *testing.T) {
+	for _, test := range rfc8032Tests {
+		priv := NewKeyFromSeed(unhex(t, test.seed))
+		public := unhex(t, test.public)
+		message := unhex(t, test.message)
+		signature := unhex(t, test.signature)
+
+		if !bytes.Equal(priv.Public(), public) {
+			t.Errorf("%s: public key %x, want %x", test.name, []byte(priv.Public()), public)
+		}
+
+		if sig := Sign(priv, message); !bytes.Equal(sig, signature) {
+			t.Errorf("%s: signature %x, want %x", test.name, sig, signature)
+		}
+
+		if !Verify(public, message, signature) {
+			t.Errorf("%s: signature does not verify", test.name)
+		}
+
+		signature[0] ^= 1
+
+		if Verify(public, message, signature) {
+			t.Errorf("%s: altered signature verifies", test.name)
+		}
+	}
+}
+
+// TestStandardLibrary checks keys and signatures match crypto/ed25519
+func TestStandardLibrary(t *testing.T) {
+	for i := 0; i < 64; i++ {
+		seed := make([]byte, SeedSize)
+		message := make([]byte, i*7)
+
+		rand.Read(seed)
+		rand.Read(message)
+
+		priv := NewKeyFromSeed(seed)
+		stdPriv := stded25519.NewKeyFromSeed(seed)
+
+		if !bytes.Equal(priv, stdPriv) {
+			t.Fatalf("seed %x: private key %x, want %x", seed, []byte(priv), []byte(stdPriv))
+		}
+
+		sig := Sign(priv, message)
+
+		if !bytes.Equal(sig, stded25519.Sign(stdPriv, message)) {
+			t.Fatalf("seed %x: signature differs from crypto/ed25519", seed)
+		}
+
+		if !stded25519.Verify(stdPriv.Public().(stded25519.PublicKey), message, sig) {
+			t.Fatalf("seed %x: crypto/ed25519 rejects the signature", seed)
+		}
+
+		sig[i%SignatureSize] ^= 0x20
+
+		if Verify(priv.Public(), message, sig) != stded25519.Verify(stdPriv.Public().(stded25519.PublicKey), message, sig) {
+			t.Fatalf("seed %x: verifying an altered signature differs from crypto/ed25519", seed)
+		}
+	}
+}
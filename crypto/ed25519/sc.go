@@ -0,0 +1,247 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package ed25519
+
+import "encoding/binary"
+
+/* Scalars are reduced modulo the group order
+   l = 2^252 + 27742317777372353535851937790883648493.
+   Internally they are handled as 21 bit limbs, the same as ref10. */
+
+const scMask int64 = 2097151
+
+// The group order l as little endian 64 bit words
+var order = [4]uint64{0x5812631a5cf5d3ed, 0x14def9dea2f79cd6, 0, 0x1000000000000000}
+
+// Reads the len(dst) 21 bit limbs packed in src, the final limb
+// takes every remaining bit
+func loadLimbs(dst []int64, src []byte) {
+	for i := range dst {
+		bit := 21 * i
+		offset := bit / 8
+
+		var v int64
+
+		for j := 0; j < 4 && offset+j < len(src); j++ {
+			v |= int64(src[offset+j]) << uint(8*j)
+		}
+
+		v >>= uint(bit % 8)
+
+		if i != len(dst)-1 {
+			v &= scMask
+		}
+
+		dst[i] = v
+	}
+}
+
+// Writes the 12 fully carried limbs in s to out
+func storeLimbs(out *[32]byte, s *[24]int64) {
+	var acc uint64
+	var bits uint
+
+	j := 0
+
+	for i := 0; i < 12; i++ {
+		acc |= uint64(s[i]) << bits
+		bits += 21
+
+		for bits >= 8 {
+			out[j] = byte(acc)
+			acc >>= 8
+			bits -= 8
+			j++
+		}
+	}
+
+	for ; j < 32; j++ {
+		out[j] = byte(acc)
+		acc >>= 8
+	}
+}
+
+// Adds s[k] * 2^(21k) back into the lower limbs using
+// 2^252 = -27742317777372353535851937790883648493 (mod l)
+func scFold(s *[24]int64, k int) {
+	s[k-12] += s[k] * 666643
+	s[k-11] += s[k] * 470296
+	s[k-10] += s[k] * 654183
+	s[k-9] -= s[k] * 997805
+	s[k-8] += s[k] * 136657
+	s[k-7] -= s[k] * 683901
+	s[k] = 0
+}
+
+// Carries s[k] into s[k+1], rounding to the nearest multiple
+func scCarry(s *[24]int64, k int) {
+	carry := (s[k] + (1 << 20)) >> 21
+	s[k+1] += carry
+	s[k] -= carry << 21
+}
+
+// Carries s[k] into s[k+1], leaving s[k] in [0, 2^21)
+func scCarryFloor(s *[24]int64, k int) {
+	carry := s[k] >> 21
+	s[k+1] += carry
+	s[k] -= carry << 21
+}
+
+// Reduces the 24 limb value s modulo l into out
+func scReduceLimbs(out *[32]byte, s *[24]int64) {
+	for k := 0; k <= 22; k += 2 {
+		scCarry(s, k)
+	}
+
+	for k := 1; k <= 21; k += 2 {
+		scCarry(s, k)
+	}
+
+	for k := 23; k >= 18; k-- {
+		scFold(s, k)
+	}
+
+	for k := 6; k <= 16; k += 2 {
+		scCarry(s, k)
+	}
+
+	for k := 7; k <= 15; k += 2 {
+		scCarry(s, k)
+	}
+
+	for k := 17; k >= 12; k-- {
+		scFold(s, k)
+	}
+
+	for k := 0; k <= 10; k += 2 {
+		scCarry(s, k)
+	}
+
+	for k := 1; k <= 11; k += 2 {
+		scCarry(s, k)
+	}
+
+	scFold(s, 12)
+
+	for k := 0; k <= 11; k++ {
+		scCarryFloor(s, k)
+	}
+
+	scFold(s, 12)
+
+	for k := 0; k <= 10; k++ {
+		scCarryFloor(s, k)
+	}
+
+	storeLimbs(out, s)
+}
+
+// ScReduce sets out = s mod l, where s is a 64 byte little endian value
+func ScReduce(out *[32]byte, s *[64]byte) {
+	var limbs [24]int64
+
+	loadLimbs(limbs[:], s[:])
+
+	scReduceLimbs(out, &limbs)
+}
+
+// ScReduce32 reduces the 32 byte little endian value s modulo l in place
+func ScReduce32(s *[32]byte) {
+	var limbs [24]int64
+
+	loadLimbs(limbs[:12], s[:])
+
+	scReduceLimbs(s, &limbs)
+}
+
+// ScAdd sets s = a + b mod l
+func ScAdd(s, a, b *[32]byte) {
+	var limbs, bLimbs [24]int64
+
+	loadLimbs(limbs[:12], a[:])
+	loadLimbs(bLimbs[:12], b[:])
+
+	for i := 0; i < 12; i++ {
+		limbs[i] += bLimbs[i]
+	}
+
+	scReduceLimbs(s, &limbs)
+}
+
+// ScSub sets s = a - b mod l
+func ScSub(s, a, b *[32]byte) {
+	var limbs, bLimbs [24]int64
+
+	loadLimbs(limbs[:12], a[:])
+	loadLimbs(bLimbs[:12], b[:])
+
+	for i := 0; i < 12; i++ {
+		limbs[i] -= bLimbs[i]
+	}
+
+	scReduceLimbs(s, &limbs)
+}
+
+// s = c + sign * a * b mod l
+func scMulAdd(s, a, b, c *[32]byte, sign int64) {
+	var aLimbs, bLimbs [12]int64
+	var limbs [24]int64
+
+	loadLimbs(aLimbs[:], a[:])
+	loadLimbs(bLimbs[:], b[:])
+	loadLimbs(limbs[:12], c[:])
+
+	for i := 0; i < 12; i++ {
+		for j := 0; j < 12; j++ {
+			limbs[i+j] += sign * aLimbs[i] * bLimbs[j]
+		}
+	}
+
+	scReduceLimbs(s, &limbs)
+}
+
+// ScMulAdd sets s = c + a * b mod l
+func ScMulAdd(s, a, b, c *[32]byte) {
+	scMulAdd(s, a, b, c, 1)
+}
+
+// ScMulSub sets s = c - a * b mod l
+func ScMulSub(s, a, b, c *[32]byte) {
+	scMulAdd(s, a, b, c, -1)
+}
+
+// ScCheck returns true if s is a canonical scalar, that is, less than l
+func ScCheck(s *[32]byte) bool {
+	for i := 3; ; i-- {
+		v := binary.LittleEndian.Uint64(s[i*8:])
+
+		if v > order[i] {
+			return false
+		} else if v < order[i] {
+			break
+		} else if i == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ScIsNonZero returns true if s is not 0
+func ScIsNonZero(s *[32]byte) bool {
+	var x byte
+
+	for _, b := range s {
+		x |= b
+	}
+
+	return x != 0
+}
@@ -95,14 +95,17 @@ func keccak(input []byte, outputSize int) []byte {
 
 	inputLength := len(input)
 
+	offset := 0
+
 	for inputLength >= rsiz {
 		for i := 0; i < rsizw; i++ {
 			/* Read 8 bytes as a ulong, need to multiply i by
 			8 because we're reading chunks of 8 at once */
-			state[i] ^= binary.LittleEndian.Uint64(input[i*8 : (i*8 + 8)])
+			state[i] ^= binary.LittleEndian.Uint64(input[offset+i*8 : (offset + i*8 + 8)])
 		}
 		Keccakf(state, -1)
 		inputLength -= rsiz
+		offset += rsiz
 	}
 
 	temp := make([]byte, 144)
@@ -110,7 +113,7 @@ func keccak(input []byte, outputSize int) []byte {
 	/* Copy inputLength bytes from input to tmp at an offset of
 	   offset from input */
 	for i := 0; i < inputLength; i++ {
-		temp[i] = input[i]
+		temp[i] = input[offset+i]
 	}
 
 	temp[inputLength] = 1
@@ -0,0 +1,47 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package keccak
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// keccakTests are hashes of the bytes 0, 1, 2, ... of each length, from
+// the legacy keccak of golang.org/x/crypto/sha3. The lengths either side
+// of the 136 byte rate check that every block of long inputs is absorbed.
+var keccakTests = []struct {
+	length int
+	hash   string
+}{
+	{0, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+	{1, "bc36789e7a1e281436464229828f817d6612f7b477d66591ff96a9e064bcc98a"},
+	{135, "cbdfd9dee5faad3818d6b06f95a219fd290b0e1706f6a82e5a595b9ce9faca62"},
+	{136, "7ce759f1ab7f9ce437719970c26b0a66ff11fe3e38e17df89cf5d29c7d7f807e"},
+	{137, "ac73d4fae68b8453f764007c1a20ce95994187861f0c3227a3a8e99a73a3b1db"},
+	{200, "bfb0aa97863e797943cf7c33bb7e880bb4543f3d2703c0923c6901c2af57b890"},
+	{271, "7c974895b2a88303ff2dc6b58f438ceb0b298cac91099ac0539cc0f477506191"},
+	{272, "fdf2ec49e749960d3c8521a0219af8d03e30e2b3bf19bd16150ee0eaf133d66e"},
+	{273, "4f707289a9c3ccd0c4a51f2f17339f5dd171d371c04ff7783b735b5b22682eaf"},
+	{700, "c6ebfa4bc0bfa2af25677e2bf95d5e92092e6a3b53039b92bc8f16636f4d42fa"},
+}
+
+func TestKeccak(t *testing.T) {
+	for _, test := range keccakTests {
+		input := make([]byte, test.length)
+
+		for i := range input {
+			input[i] = byte(i)
+		}
+
+		if got := hex.EncodeToString(Keccak(input, 32)); got != test.hash {
+			t.Errorf("%d bytes: got %s, want %s", test.length, got, test.hash)
+		}
+	}
+}
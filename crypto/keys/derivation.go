@@ -0,0 +1,151 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package keys
+
+import (
+	"errors"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keccak"
+	"github.com/turtlecoin/go-turtlecoin/varint"
+)
+
+// KeyDerivation is the shared secret 8 * r * A = 8 * a * R between the
+// sender and the receiver of a transaction
+type KeyDerivation [32]byte
+
+// ErrInvalidPublicKey is returned when a public key is not a valid
+// point on the curve
+var ErrInvalidPublicKey = errors.New("public key is not a valid point")
+
+// HashToScalar hashes data with keccak and reduces the result modulo
+// the group order
+func HashToScalar(data []byte) EllipticCurveScalar {
+	var res EllipticCurveScalar
+
+	copy(res[:], keccak.Keccak(data, 32))
+
+	ed25519.ScReduce32((*[32]byte)(&res))
+
+	return res
+}
+
+// GenerateKeyDerivation calculates the key derivation 8 * secret * public.
+// The sender uses the transaction secret key and the receivers public view
+// key, the receiver uses their secret view key and the transaction public key.
+func GenerateKeyDerivation(public PublicKey, secret SecretKey) (KeyDerivation, error) {
+	var point, point2 ed25519.ExtendedGroupElement
+	var point3 ed25519.CompletedGroupElement
+	var point4 ed25519.ProjectiveGroupElement
+	var derivation KeyDerivation
+
+	if !ed25519.ScCheck((*[32]byte)(&secret)) {
+		return KeyDerivation{}, ErrInvalidSecretKey
+	}
+
+	if !point.FromBytes((*[32]byte)(&public)) {
+		return KeyDerivation{}, ErrInvalidPublicKey
+	}
+
+	ed25519.GeScalarMult(&point2, (*[32]byte)(&secret), &point)
+
+	point2.ToProjective(&point4)
+
+	ed25519.GeMul8(&point3, &point4)
+
+	point3.ToProjective(&point4)
+	point4.ToBytes((*[32]byte)(&derivation))
+
+	return derivation, nil
+}
+
+// DerivationToScalar hashes the derivation together with the varint
+// encoded output index, giving the scalar which links an output key to
+// the receivers spend key
+func DerivationToScalar(derivation KeyDerivation, outputIndex uint64) EllipticCurveScalar {
+	buf := make([]byte, 0, len(derivation)+10)
+
+	buf = append(buf, derivation[:]...)
+	buf = varint.Append(buf, outputIndex)
+
+	return HashToScalar(buf)
+}
+
+// DerivePublicKey calculates the one time output key
+// Hs(derivation || outputIndex) * B + base
+func DerivePublicKey(derivation KeyDerivation, outputIndex uint64, base PublicKey) (PublicKey, error) {
+	var point1, point2 ed25519.ExtendedGroupElement
+	var point3 ed25519.CachedGroupElement
+	var point4 ed25519.CompletedGroupElement
+	var point5 ed25519.ProjectiveGroupElement
+	var derivedKey PublicKey
+
+	if !point1.FromBytes((*[32]byte)(&base)) {
+		return PublicKey{}, ErrInvalidPublicKey
+	}
+
+	scalar := DerivationToScalar(derivation, outputIndex)
+
+	ed25519.GeScalarMultBase(&point2, (*[32]byte)(&scalar))
+
+	point2.ToCached(&point3)
+
+	ed25519.GeAdd(&point4, &point1, &point3)
+
+	point4.ToProjective(&point5)
+	point5.ToBytes((*[32]byte)(&derivedKey))
+
+	return derivedKey, nil
+}
+
+// DeriveSecretKey calculates the secret key of the one time output key,
+// Hs(derivation || outputIndex) + base
+func DeriveSecretKey(derivation KeyDerivation, outputIndex uint64, base SecretKey) (SecretKey, error) {
+	var derivedKey SecretKey
+
+	if !ed25519.ScCheck((*[32]byte)(&base)) {
+		return SecretKey{}, ErrInvalidSecretKey
+	}
+
+	scalar := DerivationToScalar(derivation, outputIndex)
+
+	ed25519.ScAdd((*[32]byte)(&derivedKey), (*[32]byte)(&base), (*[32]byte)(&scalar))
+
+	return derivedKey, nil
+}
+
+// UnderivePublicKey is the inverse of DerivePublicKey, it recovers the
+// public spend key an output was sent to, derivedKey - Hs(derivation ||
+// outputIndex) * B. Scanning compares the result with our spend key.
+func UnderivePublicKey(derivation KeyDerivation, outputIndex uint64, derivedKey PublicKey) (PublicKey, error) {
+	var point1, point2 ed25519.ExtendedGroupElement
+	var point3 ed25519.CachedGroupElement
+	var point4 ed25519.CompletedGroupElement
+	var point5 ed25519.ProjectiveGroupElement
+	var base PublicKey
+
+	if !point1.FromBytes((*[32]byte)(&derivedKey)) {
+		return PublicKey{}, ErrInvalidPublicKey
+	}
+
+	scalar := DerivationToScalar(derivation, outputIndex)
+
+	ed25519.GeScalarMultBase(&point2, (*[32]byte)(&scalar))
+
+	point2.ToCached(&point3)
+
+	ed25519.GeSub(&point4, &point1, &point3)
+
+	point4.ToProjective(&point5)
+	point5.ToBytes((*[32]byte)(&base))
+
+	return base, nil
+}
@@ -0,0 +1,242 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package keys
+
+import (
+	"bufio"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+)
+
+// vectors returns the arguments of each line of testdata/tests.txt for
+// function name. The file has the layout of tests/crypto/tests.txt in the
+// reference code, a function name followed by its arguments and result.
+func vectors(t *testing.T, name string) [][]string {
+	t.Helper()
+
+	f, err := os.Open("testdata/tests.txt")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	var res [][]string
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) != 0 && fields[0] == name {
+			res = append(res, fields[1:])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res) == 0 {
+		t.Fatalf("no %s vectors", name)
+	}
+
+	return res
+}
+
+// fromHex decodes s into dst, failing the test if it isn't len(dst) bytes
+// of hex
+func fromHex(t *testing.T, dst []byte, s string) {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+
+	if err != nil || len(b) != len(dst) {
+		t.Fatalf("bad hex %q", s)
+	}
+
+	copy(dst, b)
+}
+
+func TestHashToScalar(t *testing.T) {
+	for _, v := range vectors(t, "hash_to_scalar") {
+		data, err := hex.DecodeString(v[0])
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res := HashToScalar(data); hex.EncodeToString(res[:]) != v[1] {
+			t.Errorf("HashToScalar(%s) = %x, want %s", v[0], res, v[1])
+		}
+	}
+}
+
+// walletOutput is output 1 of a stagenet transaction received by a wallet
+// whose keys are known, as used by the tests of chekist32/go-monero
+var walletOutput = struct {
+	txPublic    string
+	viewSecret  string
+	spendPublic string
+	spendSecret string
+	index       uint64
+	output      string
+}{
+	txPublic:    "7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364",
+	viewSecret:  "8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009",
+	spendPublic: "38e9908d33d034de0ba1281aa7afe3907b795cea14852b3d8fe276e8931cb130",
+	spendSecret: "372fcc2abc6bc5015103aae4763822e45c4cfe775d163f97a9ebdd77b0d12c0c",
+	index:       1,
+	output:      "7e4f4427539b206740bed78b81b0dc10acb89aa1545880863f73264492ee0c16",
+}
+
+// TestWalletOutput checks the receiver of an output on the chain derives
+// its key, recovers their spend key from it and can derive its secret key
+func TestWalletOutput(t *testing.T) {
+	var txPublic, spendPublic, output PublicKey
+	var viewSecret, spendSecret SecretKey
+
+	fromHex(t, txPublic[:], walletOutput.txPublic)
+	fromHex(t, viewSecret[:], walletOutput.viewSecret)
+	fromHex(t, spendPublic[:], walletOutput.spendPublic)
+	fromHex(t, spendSecret[:], walletOutput.spendSecret)
+	fromHex(t, output[:], walletOutput.output)
+
+	derivation, err := GenerateKeyDerivation(txPublic, viewSecret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if derived, err := DerivePublicKey(derivation, walletOutput.index, spendPublic); err != nil || derived != output {
+		t.Errorf("DerivePublicKey = %v, %v, want %v", derived, err, output)
+	}
+
+	if base, err := UnderivePublicKey(derivation, walletOutput.index, output); err != nil || base != spendPublic {
+		t.Errorf("UnderivePublicKey = %v, %v, want %v", base, err, spendPublic)
+	}
+
+	secret, err := DeriveSecretKey(derivation, walletOutput.index, spendSecret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if public, _ := SecretKeyToPublicKey(secret); public != output {
+		t.Errorf("DeriveSecretKey gives the secret key of %v, want %v", public, output)
+	}
+
+	// the output belongs to no other index
+	if derived, _ := DerivePublicKey(derivation, walletOutput.index+1, spendPublic); derived == output {
+		t.Error("output derived at the next index")
+	}
+}
+
+// TestDerivation checks the sender and the receiver calculate the same
+// derivation, and that UnderivePublicKey undoes DerivePublicKey
+func TestDerivation(t *testing.T) {
+	txPublic, txSecret, err := GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spendPublic, spendSecret, err := GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viewPublic, viewSecret, err := GenerateViewFromSpend(spendSecret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := GenerateKeyDerivation(viewPublic, txSecret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receiver, err := GenerateKeyDerivation(txPublic, viewSecret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sender != receiver {
+		t.Fatalf("sender derivation %x, receiver derivation %x", sender, receiver)
+	}
+
+	// indexes of one and several varint bytes
+	for _, index := range []uint64{0, 1, 127, 128, 300, 1 << 40} {
+		output, err := DerivePublicKey(sender, index, spendPublic)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if base, err := UnderivePublicKey(receiver, index, output); err != nil || base != spendPublic {
+			t.Errorf("index %d: UnderivePublicKey = %v, %v, want %v", index, base, err, spendPublic)
+		}
+
+		secret, err := DeriveSecretKey(receiver, index, spendSecret)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if public, _ := SecretKeyToPublicKey(secret); public != output {
+			t.Errorf("index %d: secret key of %v, want %v", index, public, output)
+		}
+	}
+}
+
+func TestDerivationErrors(t *testing.T) {
+	var notPoint PublicKey
+	var unreduced SecretKey
+	var derivation KeyDerivation
+
+	// y = 2 is not the y coordinate of a point on the curve
+	notPoint[0] = 2
+
+	for i := range unreduced {
+		unreduced[i] = 0xff
+	}
+
+	public, secret, err := GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GenerateKeyDerivation(notPoint, secret); err != ErrInvalidPublicKey {
+		t.Errorf("GenerateKeyDerivation: err = %v, want %v", err, ErrInvalidPublicKey)
+	}
+
+	if _, err := GenerateKeyDerivation(public, unreduced); err != ErrInvalidSecretKey {
+		t.Errorf("GenerateKeyDerivation: err = %v, want %v", err, ErrInvalidSecretKey)
+	}
+
+	if _, err := DerivePublicKey(derivation, 0, notPoint); err != ErrInvalidPublicKey {
+		t.Errorf("DerivePublicKey: err = %v, want %v", err, ErrInvalidPublicKey)
+	}
+
+	if _, err := UnderivePublicKey(derivation, 0, notPoint); err != ErrInvalidPublicKey {
+		t.Errorf("UnderivePublicKey: err = %v, want %v", err, ErrInvalidPublicKey)
+	}
+
+	if _, err := DeriveSecretKey(derivation, 0, unreduced); err != ErrInvalidSecretKey {
+		t.Errorf("DeriveSecretKey: err = %v, want %v", err, ErrInvalidSecretKey)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package keys
+
+import (
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keccak"
+)
+
+// KeyImage is x * Hp(P) for the one time output key P = x * B. Every
+// output has exactly one key image, so it is used to detect double spends.
+type KeyImage [32]byte
+
+// curveOrder is l, the order of the prime order subgroup, little endian
+var curveOrder = [32]byte{
+	0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+	0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10}
+
+// identity is the encoding of the neutral element
+var identity = [32]byte{1}
+
+// HashToEC hashes a public key to a point in the prime order subgroup
+func HashToEC(public PublicKey) ed25519.ExtendedGroupElement {
+	var hash [32]byte
+	var point ed25519.ProjectiveGroupElement
+	var point2 ed25519.CompletedGroupElement
+	var res ed25519.ExtendedGroupElement
+
+	copy(hash[:], keccak.Keccak(public[:], 32))
+
+	ed25519.GeFromFeFromBytesVartime(&point, &hash)
+	ed25519.GeMul8(&point2, &point)
+
+	point2.ToExtended(&res)
+
+	return res
+}
+
+// GenerateKeyImage calculates the key image secret * Hp(public) of the
+// one time output key public, whose secret key is secret
+func GenerateKeyImage(public PublicKey, secret SecretKey) (KeyImage, error) {
+	var point ed25519.ExtendedGroupElement
+	var image KeyImage
+
+	if !ed25519.ScCheck((*[32]byte)(&secret)) {
+		return KeyImage{}, ErrInvalidSecretKey
+	}
+
+	hashed := HashToEC(public)
+
+	ed25519.GeScalarMult(&point, (*[32]byte)(&secret), &hashed)
+
+	point.ToBytes((*[32]byte)(&image))
+
+	return image, nil
+}
+
+// CheckKeyImage returns true if image is a valid point in the prime order
+// subgroup, l * image == identity. Key images outside the subgroup could
+// be used to spend the same output several times, under different images.
+func CheckKeyImage(image KeyImage) bool {
+	var point, point2 ed25519.ExtendedGroupElement
+	var res [32]byte
+
+	if !point.FromBytes((*[32]byte)(&image)) {
+		return false
+	}
+
+	ed25519.GeScalarMult(&point2, &curveOrder, &point)
+
+	point2.ToBytes(&res)
+
+	return res == identity
+}
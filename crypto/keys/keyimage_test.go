@@ -0,0 +1,143 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+)
+
+func TestHashToEC(t *testing.T) {
+	for _, v := range vectors(t, "hash_to_ec") {
+		var public PublicKey
+		var res [32]byte
+
+		fromHex(t, public[:], v[0])
+
+		point := HashToEC(public)
+		point.ToBytes(&res)
+
+		if hex.EncodeToString(res[:]) != v[1] {
+			t.Errorf("HashToEC(%s) = %x, want %s", v[0], res, v[1])
+		}
+	}
+}
+
+// smallOrder are encodings of points of order 2, 4 and 8
+var smallOrder = []string{
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"0000000000000000000000000000000000000000000000000000000000000080",
+	"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05",
+	"c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a",
+}
+
+// addPoint returns image + the point encoded as s
+func addPoint(t *testing.T, image KeyImage, s string) KeyImage {
+	t.Helper()
+
+	var p, q ed25519.ExtendedGroupElement
+	var cached ed25519.CachedGroupElement
+	var sum ed25519.CompletedGroupElement
+	var enc [32]byte
+	var res KeyImage
+
+	fromHex(t, enc[:], s)
+
+	if !p.FromBytes((*[32]byte)(&image)) || !q.FromBytes(&enc) {
+		t.Fatalf("%x or %s is not a point", image, s)
+	}
+
+	q.ToCached(&cached)
+	ed25519.GeAdd(&sum, &p, &cached)
+	sum.ToExtended(&p)
+	p.ToBytes((*[32]byte)(&res))
+
+	return res
+}
+
+func TestGenerateKeyImage(t *testing.T) {
+	public, secret, err := GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image, err := GenerateKeyImage(public, secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !CheckKeyImage(image) {
+		t.Errorf("key image %x is not valid", image)
+	}
+
+	// the image depends on both the public key and the secret key
+	otherPublic, otherSecret, err := GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if other, _ := GenerateKeyImage(otherPublic, secret); other == image {
+		t.Error("key images of different public keys are equal")
+	}
+
+	if other, _ := GenerateKeyImage(public, otherSecret); other == image {
+		t.Error("key images of different secret keys are equal")
+	}
+
+	var unreduced SecretKey
+
+	for i := range unreduced {
+		unreduced[i] = 0xff
+	}
+
+	if _, err := GenerateKeyImage(public, unreduced); err != ErrInvalidSecretKey {
+		t.Errorf("err = %v, want %v", err, ErrInvalidSecretKey)
+	}
+}
+
+// TestCheckKeyImage checks key images outside the prime order subgroup are
+// rejected, which would let one output be spent under several images
+func TestCheckKeyImage(t *testing.T) {
+	public, secret, err := GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image, err := GenerateKeyImage(public, secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range smallOrder {
+		var small KeyImage
+
+		fromHex(t, small[:], s)
+
+		if CheckKeyImage(small) {
+			t.Errorf("point of small order %s accepted", s)
+		}
+
+		if torsion := addPoint(t, image, s); CheckKeyImage(torsion) {
+			t.Errorf("key image plus %s accepted", s)
+		}
+	}
+
+	// y = 2 is not the y coordinate of a point on the curve
+	if CheckKeyImage(KeyImage{2}) {
+		t.Error("key image which isn't a point accepted")
+	}
+}
@@ -0,0 +1,117 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+// Package keys implements CryptoNote wallet keys, the Go equivalent
+// of the key handling in crypto.cpp
+package keys
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keccak"
+)
+
+// PublicKey is an encoded point on the ed25519 curve
+type PublicKey [32]byte
+
+// SecretKey is a scalar, reduced modulo the group order
+type SecretKey [32]byte
+
+// EllipticCurveScalar is a scalar, reduced modulo the group order
+type EllipticCurveScalar [32]byte
+
+// Hash is a 32 byte keccak hash, such as a transaction prefix hash
+type Hash [32]byte
+
+// ErrInvalidSecretKey is returned when a secret key is not
+// a canonical scalar
+var ErrInvalidSecretKey = errors.New("secret key is not a reduced scalar")
+
+// String returns the key as hex, as the wallets display it
+func (k PublicKey) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// String returns the key as hex, as the wallets display it
+func (k SecretKey) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// RandomScalar returns a uniformly random scalar, by reducing 64 random
+// bytes modulo the group order
+func RandomScalar() (EllipticCurveScalar, error) {
+	var tmp [64]byte
+	var res EllipticCurveScalar
+
+	if _, err := io.ReadFull(rand.Reader, tmp[:]); err != nil {
+		return EllipticCurveScalar{}, err
+	}
+
+	ed25519.ScReduce((*[32]byte)(&res), &tmp)
+
+	return res, nil
+}
+
+// GenerateKeys generates a random key pair
+func GenerateKeys() (PublicKey, SecretKey, error) {
+	scalar, err := RandomScalar()
+
+	if err != nil {
+		return PublicKey{}, SecretKey{}, err
+	}
+
+	secret := SecretKey(scalar)
+
+	public, err := SecretKeyToPublicKey(secret)
+
+	return public, secret, err
+}
+
+// GenerateViewFromSpend derives the view key pair from the private spend
+// key, the view secret being sc_reduce32(keccak(spendSecret)). Wallets
+// created this way can be restored from the spend key alone.
+func GenerateViewFromSpend(spend SecretKey) (PublicKey, SecretKey, error) {
+	var secret SecretKey
+
+	copy(secret[:], keccak.Keccak(spend[:], 32))
+
+	ed25519.ScReduce32((*[32]byte)(&secret))
+
+	public, err := SecretKeyToPublicKey(secret)
+
+	return public, secret, err
+}
+
+// SecretKeyToPublicKey calculates the public key of secret, secret * B
+func SecretKeyToPublicKey(secret SecretKey) (PublicKey, error) {
+	var point ed25519.ExtendedGroupElement
+	var public PublicKey
+
+	if !ed25519.ScCheck((*[32]byte)(&secret)) {
+		return PublicKey{}, ErrInvalidSecretKey
+	}
+
+	ed25519.GeScalarMultBase(&point, (*[32]byte)(&secret))
+
+	point.ToBytes((*[32]byte)(&public))
+
+	return public, nil
+}
+
+// CheckKey returns true if public is a valid point on the curve
+func CheckKey(public PublicKey) bool {
+	var point ed25519.ExtendedGroupElement
+
+	return point.FromBytes((*[32]byte)(&public))
+}
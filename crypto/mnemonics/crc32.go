@@ -37,8 +37,8 @@ var table = []uint{
 func crc32(input string) uint {
 	var crc uint = 0xFFFFFFFF
 
-	for c := range input {
-		byteIndex := ((uint(c) ^ crc) >> 0) & 0xff
+	for i := 0; i < len(input); i++ {
+		byteIndex := ((uint(input[i]) ^ crc) >> 0) & 0xff
 		crc = ((crc >> 8) ^ table[byteIndex]) >> 0
 	}
 
@@ -0,0 +1,241 @@
+/*
+
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// WordListLength is the number of words in the list of every language
+const WordListLength = 1626
+
+// ErrWordListLength is returned when registering a word list which doesn't
+// have WordListLength words
+var ErrWordListLength = errors.New("word list must have 1626 words")
+
+// ErrWordListPrefixes is returned when registering a word list whose words
+// can't be told apart by their prefixes
+var ErrWordListPrefixes = errors.New("word list prefixes are not unique")
+
+// ErrDuplicateLanguage is returned when registering a language which is
+// already registered
+var ErrDuplicateLanguage = errors.New("language is already registered")
+
+// ErrUnknownLanguage is returned when the words of a seed are not all from
+// the list of one language
+var ErrUnknownLanguage = errors.New("mnemonic seed words are not from a known language")
+
+// Language is a word list that mnemonic seeds can be written with
+type Language struct {
+	// Name is the name of the language, in the language itself
+	Name string
+	// EnglishName is the name of the language, in English
+	EnglishName string
+	// PrefixLength is the number of letters at the start of each word
+	// which are unique within the list, and which are used in the checksum
+	PrefixLength int
+
+	words      []string
+	normalised []string
+	indexes    map[string]int
+	prefixes   map[string]int
+}
+
+// English is the default language, the only one which is built in
+var English *Language
+
+// languages are the registered languages, in the order they were added
+var languages []*Language
+
+var languagesMutex sync.RWMutex
+
+func init() {
+	var err error
+
+	if English, err = newLanguage("English", "English", 3, english); err != nil {
+		panic(err)
+	}
+
+	languages = []*Language{English}
+}
+
+// normalise returns word in the form it is compared in, lower case and
+// NFKD normalised, so the same accented letters typed in different ways
+// match
+func normalise(word string) string {
+	return norm.NFKD.String(strings.ToLower(word))
+}
+
+// prefix returns the first n letters of word
+func prefix(word string, n int) string {
+	for i := range word {
+		if n == 0 {
+			return word[:i]
+		}
+
+		n--
+	}
+
+	return word
+}
+
+// newLanguage checks a word list and builds the lookup tables for it
+func newLanguage(name, englishName string, prefixLength int, words []string) (*Language, error) {
+	if len(words) != WordListLength {
+		return nil, ErrWordListLength
+	}
+
+	l := &Language{
+		Name:         name,
+		EnglishName:  englishName,
+		PrefixLength: prefixLength,
+		words:        make([]string, len(words)),
+		normalised:   make([]string, len(words)),
+		indexes:      make(map[string]int, len(words)),
+		prefixes:     make(map[string]int, len(words)),
+	}
+
+	// words are kept as given, as the checksum is calculated from them,
+	// but compared in normalised form
+	for i, word := range words {
+		normalised := normalise(word)
+		start := normalise(prefix(word, prefixLength))
+
+		if _, ok := l.prefixes[start]; ok {
+			return nil, ErrWordListPrefixes
+		}
+
+		l.words[i] = word
+		l.normalised[i] = normalised
+		l.indexes[normalised] = i
+		l.prefixes[start] = i
+	}
+
+	return l, nil
+}
+
+// RegisterLanguage adds a word list, such as one of the other lists used
+// by the CryptoNote wallets, so seeds can be written and read in it. The
+// words are used in the order given, which must match the wallets.
+func RegisterLanguage(name, englishName string, prefixLength int, words []string) (*Language, error) {
+	l, err := newLanguage(name, englishName, prefixLength, words)
+
+	if err != nil {
+		return nil, err
+	}
+
+	languagesMutex.Lock()
+	defer languagesMutex.Unlock()
+
+	for _, other := range languages {
+		if strings.EqualFold(other.EnglishName, englishName) {
+			return nil, ErrDuplicateLanguage
+		}
+	}
+
+	languages = append(languages, l)
+
+	return l, nil
+}
+
+// Languages returns the registered languages
+func Languages() []*Language {
+	languagesMutex.RLock()
+	defer languagesMutex.RUnlock()
+
+	res := make([]*Language, len(languages))
+
+	copy(res, languages)
+
+	return res
+}
+
+// LanguageByName returns the registered language called name, either in
+// the language itself or in English
+func LanguageByName(name string) (*Language, bool) {
+	for _, l := range Languages() {
+		if strings.EqualFold(l.Name, name) || strings.EqualFold(l.EnglishName, name) {
+			return l, true
+		}
+	}
+
+	return nil, false
+}
+
+// Words returns the word list of the language
+func (l *Language) Words() []string {
+	res := make([]string, len(l.words))
+
+	copy(res, l.words)
+
+	return res
+}
+
+// DetectLanguage returns the language the words of mnemonic are from.
+// Languages where every word is complete are preferred over ones where
+// some words are only prefixes.
+func DetectLanguage(mnemonic string) (*Language, error) {
+	l, unknown := detectLanguage(strings.Fields(mnemonic))
+
+	if unknown >= 0 {
+		return nil, ErrUnknownLanguage
+	}
+
+	return l, nil
+}
+
+// detectLanguage returns the language of words and -1, or if no language
+// matches every word, the language matching the most words and the index
+// of the first word which isn't in it
+func detectLanguage(words []string) (*Language, int) {
+	var best *Language
+
+	bestCount, bestUnknown := -1, 0
+
+	for _, exact := range []bool{true, false} {
+		for _, l := range Languages() {
+			count, unknown := 0, -1
+
+			for i, word := range words {
+				var err error
+
+				if exact {
+					_, ok := l.indexes[normalise(word)]
+
+					if !ok {
+						err = ErrUnknownWord
+					}
+				} else {
+					_, err = l.resolveWord(word)
+				}
+
+				if err == nil {
+					count++
+				} else if unknown < 0 {
+					unknown = i
+				}
+			}
+
+			if unknown < 0 {
+				return l, -1
+			}
+
+			if count > bestCount {
+				best, bestCount, bestUnknown = l, count, unknown
+			}
+		}
+	}
+
+	return best, bestUnknown
+}
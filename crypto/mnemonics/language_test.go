@@ -0,0 +1,129 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+var accented *Language
+
+var accentedOnce sync.Once
+
+// accentedWords returns a word list of accented words, written with
+// precomposed letters, whose first 6 letters are unique
+func accentedWords() []string {
+	words := make([]string, WordListLength)
+
+	for i := range words {
+		words[i] = fmt.Sprintf("ñú%04dçé", i)
+	}
+
+	return words
+}
+
+// accentedLanguage registers the accented word list, once however many
+// times the tests are run
+func accentedLanguage(t *testing.T) *Language {
+	t.Helper()
+
+	accentedOnce.Do(func() {
+		var err error
+
+		if accented, err = RegisterLanguage("Acentuado", "Accented", 6, accentedWords()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if accented == nil {
+		t.Fatal("accented language is not registered")
+	}
+
+	return accented
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	accentedLanguage(t)
+
+	if _, err := RegisterLanguage("Inglés", "english", 3, English.Words()); err != ErrDuplicateLanguage {
+		t.Errorf("duplicate language: err = %v, want %v", err, ErrDuplicateLanguage)
+	}
+
+	if _, err := RegisterLanguage("Short", "Short", 3, English.Words()[1:]); err != ErrWordListLength {
+		t.Errorf("short list: err = %v, want %v", err, ErrWordListLength)
+	}
+
+	if _, err := RegisterLanguage("Prefixes", "Prefixes", 2, accentedWords()); err != ErrWordListPrefixes {
+		t.Errorf("prefixes not unique: err = %v, want %v", err, ErrWordListPrefixes)
+	}
+
+	for _, name := range []string{"Acentuado", "ACCENTED", "english"} {
+		if _, ok := LanguageByName(name); !ok {
+			t.Errorf("LanguageByName(%q) not found", name)
+		}
+	}
+}
+
+// TestAccentedSeed checks seeds in a list with accented letters can be
+// read however the letters are typed, and that their language is found
+func TestAccentedSeed(t *testing.T) {
+	l := accentedLanguage(t)
+
+	_, secret, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mnemonic, err := l.PrivateKeyToMnemonic(secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decomposed := norm.NFD.String(mnemonic)
+
+	if decomposed == mnemonic {
+		t.Fatal("decomposing the accented letters did not change the seed")
+	}
+
+	for _, typed := range []string{mnemonic, decomposed, strings.ToUpper(decomposed)} {
+		if detected, err := DetectLanguage(typed); err != nil || detected != l {
+			t.Errorf("DetectLanguage(%q) = %v, %v, want %s", typed, detected, err, l.EnglishName)
+		}
+
+		if key, err := MnemonicToPrivateKey(typed); err != nil || key != secret {
+			t.Errorf("MnemonicToPrivateKey(%q) = %v, %v, want %v", typed, key, err, secret)
+		}
+	}
+
+	english, err := PrivateKeyToMnemonic(secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if detected, err := DetectLanguage(english); err != nil || detected != English {
+		t.Errorf("DetectLanguage of an English seed = %v, %v", detected, err)
+	}
+
+	mixed := strings.Fields(mnemonic)
+	mixed[0] = strings.Fields(english)[0]
+
+	if _, err := DetectLanguage(strings.Join(mixed, " ")); err != ErrUnknownLanguage {
+		t.Errorf("mixed languages: err = %v, want %v", err, ErrUnknownLanguage)
+	}
+}
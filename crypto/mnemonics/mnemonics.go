@@ -0,0 +1,177 @@
+/*
+
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+// Package mnemonics converts private keys to and from the 25 word
+// mnemonic seeds used by the TurtleCoin wallets
+package mnemonics
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// ErrWrongLength is returned when a mnemonic seed isn't 25 or 31 words long
+var ErrWrongLength = errors.New("mnemonic seed must be 25 or 31 words long")
+
+// ErrBadChecksum is returned when the last word of a mnemonic seed doesn't
+// match the checksum of the others
+var ErrBadChecksum = errors.New("mnemonic seed checksum word is wrong")
+
+// ErrInvalidKey is returned when the words of a mnemonic seed don't encode
+// a valid private key
+var ErrInvalidKey = errors.New("mnemonic seed is not a valid private key")
+
+// UnknownWordError is returned when a mnemonic seed contains a word which
+// is not in the word list
+type UnknownWordError struct {
+	// Position is the position of the word in the seed, starting at 1
+	Position int
+	Word     string
+}
+
+func (e *UnknownWordError) Error() string {
+	return "mnemonic seed word " + strconv.Itoa(e.Position) + " (" + e.Word + ") is not in the word list"
+}
+
+// checksumIndex returns the index of the word to use as the checksum of
+// words, chosen by the crc32 of the prefixes of the words
+func (l *Language) checksumIndex(words []string) int {
+	var prefixes strings.Builder
+
+	for _, word := range words {
+		prefixes.WriteString(prefix(word, l.PrefixLength))
+	}
+
+	return int(crc32(prefixes.String()) % uint(len(words)))
+}
+
+// bytesToMnemonic encodes data, whose length is a multiple of 4, as
+// words. Every 4 bytes, read as a little endian integer x, become 3 words
+// w1 = x mod n, w2 = (x / n + w1) mod n and w3 = (x / n^2 + w2) mod n,
+// where n is the number of words in the list. The last word is a copy of
+// one of the others, used as a checksum.
+func (l *Language) bytesToMnemonic(data []byte) string {
+	n := uint32(len(l.words))
+	words := make([]string, 0, len(data)/4*3+1)
+
+	for i := 0; i < len(data); i += 4 {
+		x := binary.LittleEndian.Uint32(data[i : i+4])
+
+		w1 := x % n
+		w2 := (x/n + w1) % n
+		w3 := (x/n/n + w2) % n
+
+		words = append(words, l.words[w1], l.words[w2], l.words[w3])
+	}
+
+	words = append(words, words[l.checksumIndex(words)])
+
+	return strings.Join(words, " ")
+}
+
+// indexesToBytes checks the checksum of the words with the given indexes
+// in the word list and decodes the data, see bytesToMnemonic
+func (l *Language) indexesToBytes(w []int) ([]byte, error) {
+	words := make([]string, len(w))
+
+	for i, index := range w {
+		words[i] = l.words[index]
+	}
+
+	last := len(words) - 1
+
+	if words[last] != words[l.checksumIndex(words[:last])] {
+		return nil, ErrBadChecksum
+	}
+
+	n := uint64(len(l.words))
+	data := make([]byte, last/3*4)
+
+	for i := 0; i < last; i += 3 {
+		w1, w2, w3 := uint64(w[i]), uint64(w[i+1]), uint64(w[i+2])
+
+		x := w1 + n*((n-w1+w2)%n) + n*n*((n-w2+w3)%n)
+
+		// three words can encode more than 32 bits, those combinations
+		// are never created by bytesToMnemonic
+		if x%n != w1 || x > 0xffffffff {
+			return nil, ErrInvalidKey
+		}
+
+		binary.LittleEndian.PutUint32(data[i/3*4:], uint32(x))
+	}
+
+	return data, nil
+}
+
+// mnemonicToIndexes splits mnemonic into words, detects the language and
+// returns the index of each word in its list. lengthErr is returned if the
+// number of words isn't one of lengths.
+func mnemonicToIndexes(mnemonic string, lengthErr error, lengths ...int) (*Language, []int, error) {
+	words := strings.Fields(mnemonic)
+
+	valid := false
+
+	for _, length := range lengths {
+		if len(words) == length {
+			valid = true
+		}
+	}
+
+	if !valid {
+		return nil, nil, lengthErr
+	}
+
+	l, unknown := detectLanguage(words)
+
+	if unknown >= 0 {
+		return nil, nil, &UnknownWordError{Position: unknown + 1, Word: words[unknown]}
+	}
+
+	w := make([]int, len(words))
+
+	for i, word := range words {
+		w[i], _ = l.resolveWord(word)
+	}
+
+	return l, w, nil
+}
+
+// PrivateKeyToMnemonic converts a private key to its 25 word mnemonic seed
+// in English, see Language.PrivateKeyToMnemonic
+func PrivateKeyToMnemonic(key keys.SecretKey) (string, error) {
+	return English.PrivateKeyToMnemonic(key)
+}
+
+// PrivateKeyToMnemonic converts a private key to its 25 word mnemonic
+// seed, in the format used by the wallets, which doesn't record when the
+// wallet was created. See SeedToMnemonic for the newer format.
+func (l *Language) PrivateKeyToMnemonic(key keys.SecretKey) (string, error) {
+	if !ed25519.ScCheck((*[32]byte)(&key)) {
+		return "", keys.ErrInvalidSecretKey
+	}
+
+	return l.bytesToMnemonic(key[:]), nil
+}
+
+// MnemonicToPrivateKey converts a mnemonic seed, in either format, back to
+// the private key it was created from, checking the words and the
+// checksum. The language is detected from the words. Words are separated
+// by whitespace, are not case sensitive and may be abbreviated, see
+// Language.ResolveWord.
+func MnemonicToPrivateKey(mnemonic string) (keys.SecretKey, error) {
+	seed, err := MnemonicToSeed(mnemonic)
+
+	return seed.Key, err
+}
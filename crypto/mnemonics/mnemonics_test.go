@@ -0,0 +1,144 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// officialSeed is a seed written by the Monero wallet, which uses the same
+// English word list and encoding, and the private spend key it restores
+// to, as used by the tests of chekist32/go-monero
+var officialSeed = struct {
+	mnemonic string
+	key      string
+}{
+	mnemonic: "wiggle drowning auburn aquarium attire meant impel phase soothe heron android mechanic inroads energy smog niece enforce syllabus exquisite lush bluntly rage siblings soda syllabus",
+	key:      "0cca07dc4e90fc738fffdb2561dddd7a94d0dc8977d0229303d7509a10c9d705",
+}
+
+// fromHex decodes s into a key, failing the test if it isn't 32 bytes of
+// hex
+func fromHex(t *testing.T, s string) keys.SecretKey {
+	t.Helper()
+
+	var res keys.SecretKey
+
+	b, err := hex.DecodeString(s)
+
+	if err != nil || len(b) != len(res) {
+		t.Fatalf("bad hex %q", s)
+	}
+
+	copy(res[:], b)
+
+	return res
+}
+
+func TestPrivateKeyToMnemonic(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+
+	if mnemonic, err := PrivateKeyToMnemonic(key); err != nil || mnemonic != officialSeed.mnemonic {
+		t.Errorf("PrivateKeyToMnemonic = %q, %v, want %q", mnemonic, err, officialSeed.mnemonic)
+	}
+
+	// the largest scalar, l - 1, and l, which is not reduced
+	if _, err := PrivateKeyToMnemonic(fromHex(t, "ecd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")); err != nil {
+		t.Errorf("l - 1: %v", err)
+	}
+
+	if _, err := PrivateKeyToMnemonic(fromHex(t, "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")); err != keys.ErrInvalidSecretKey {
+		t.Errorf("l: err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+}
+
+func TestMnemonicToPrivateKey(t *testing.T) {
+	want := fromHex(t, officialSeed.key)
+	words := strings.Fields(officialSeed.mnemonic)
+
+	// the words abbreviated to the first 3 letters, and in upper case
+	var abbreviated []string
+
+	for _, word := range words {
+		abbreviated = append(abbreviated, prefix(word, English.PrefixLength))
+	}
+
+	for _, mnemonic := range []string{
+		officialSeed.mnemonic,
+		strings.Join(abbreviated, " "),
+		strings.ToUpper(officialSeed.mnemonic),
+		"  " + strings.Join(words, "\n\t ") + "\n",
+	} {
+		if key, err := MnemonicToPrivateKey(mnemonic); err != nil || key != want {
+			t.Errorf("MnemonicToPrivateKey(%q) = %v, %v, want %v", mnemonic, key, err, want)
+		}
+	}
+}
+
+// withWord returns the official seed with word i replaced
+func withWord(i int, word string) string {
+	words := strings.Fields(officialSeed.mnemonic)
+	words[i] = word
+
+	return strings.Join(words, " ")
+}
+
+func TestMnemonicToPrivateKeyErrors(t *testing.T) {
+	words := strings.Fields(officialSeed.mnemonic)
+
+	for _, mnemonic := range []string{
+		"",
+		strings.Join(words[:24], " "),
+		officialSeed.mnemonic + " " + words[0],
+		officialSeed.mnemonic + strings.Repeat(" "+words[0], 5),
+	} {
+		if _, err := MnemonicToPrivateKey(mnemonic); err != ErrWrongLength {
+			t.Errorf("%d words: err = %v, want %v", len(strings.Fields(mnemonic)), err, ErrWrongLength)
+		}
+	}
+
+	if _, err := MnemonicToPrivateKey(withWord(6, "qqqq")); !reflect.DeepEqual(err, &UnknownWordError{Position: 7, Word: "qqqq"}) {
+		t.Errorf("unknown word: err = %v, want word 7 (qqqq)", err)
+	}
+
+	// the checksum word is a copy of word 18
+	if _, err := MnemonicToPrivateKey(withWord(24, words[0])); err != ErrBadChecksum {
+		t.Errorf("wrong checksum word: err = %v, want %v", err, ErrBadChecksum)
+	}
+
+	if _, err := MnemonicToPrivateKey(withWord(17, "ability")); err != ErrBadChecksum {
+		t.Errorf("word the checksum is a copy of changed: err = %v, want %v", err, ErrBadChecksum)
+	}
+
+	// a key which is not reduced modulo the group order
+	unreduced := make([]byte, 32)
+
+	for i := range unreduced {
+		unreduced[i] = 0xff
+	}
+
+	if _, err := MnemonicToPrivateKey(English.bytesToMnemonic(unreduced)); err != ErrInvalidKey {
+		t.Errorf("key not reduced: err = %v, want %v", err, ErrInvalidKey)
+	}
+
+	// words 1, 0 and n - 1 of the list encode 1 + n * (n - 1) + n^2 * (n - 1),
+	// which is more than 32 bits
+	list := English.Words()
+	overflow := append([]string{list[1], list[0], list[WordListLength-1]}, words[3:24]...)
+	overflow = append(overflow, overflow[English.checksumIndex(overflow)])
+
+	if _, err := MnemonicToPrivateKey(strings.Join(overflow, " ")); err != ErrInvalidKey {
+		t.Errorf("words encoding more than 32 bits: err = %v, want %v", err, ErrInvalidKey)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"golang.org/x/text/unicode/norm"
+)
+
+// A seed can be protected with a passphrase, as with the seed offset of
+// the Monero wallets. The key written in the seed is the private key plus
+// Hs(passphrase), so the seed alone does not give the real key, and a
+// wrong passphrase gives a different, valid looking, wallet.
+
+// passphraseScalar returns Hs(passphrase), the keccak hash of the NFKD
+// normalised passphrase reduced modulo the group order
+func passphraseScalar(passphrase string) keys.EllipticCurveScalar {
+	return keys.HashToScalar([]byte(norm.NFKD.String(passphrase)))
+}
+
+// EncryptPrivateKey returns key + Hs(passphrase), the key which is written
+// in the seed. An empty passphrase leaves key unchanged.
+func EncryptPrivateKey(key keys.SecretKey, passphrase string) (keys.SecretKey, error) {
+	if !ed25519.ScCheck((*[32]byte)(&key)) {
+		return keys.SecretKey{}, keys.ErrInvalidSecretKey
+	}
+
+	if passphrase == "" {
+		return key, nil
+	}
+
+	offset := passphraseScalar(passphrase)
+
+	ed25519.ScAdd((*[32]byte)(&key), (*[32]byte)(&key), (*[32]byte)(&offset))
+
+	return key, nil
+}
+
+// DecryptPrivateKey returns key - Hs(passphrase), the private key from the
+// key read from a seed. An empty passphrase leaves key unchanged.
+func DecryptPrivateKey(key keys.SecretKey, passphrase string) (keys.SecretKey, error) {
+	if !ed25519.ScCheck((*[32]byte)(&key)) {
+		return keys.SecretKey{}, keys.ErrInvalidSecretKey
+	}
+
+	if passphrase == "" {
+		return key, nil
+	}
+
+	offset := passphraseScalar(passphrase)
+
+	ed25519.ScSub((*[32]byte)(&key), (*[32]byte)(&key), (*[32]byte)(&offset))
+
+	return key, nil
+}
+
+// PrivateKeyToEncryptedMnemonic converts a private key to an English
+// mnemonic seed protected by passphrase
+func PrivateKeyToEncryptedMnemonic(key keys.SecretKey, passphrase string) (string, error) {
+	return English.PrivateKeyToEncryptedMnemonic(key, passphrase)
+}
+
+// PrivateKeyToEncryptedMnemonic converts a private key to a mnemonic seed
+// protected by passphrase
+func (l *Language) PrivateKeyToEncryptedMnemonic(key keys.SecretKey, passphrase string) (string, error) {
+	encrypted, err := EncryptPrivateKey(key, passphrase)
+
+	if err != nil {
+		return "", err
+	}
+
+	return l.PrivateKeyToMnemonic(encrypted)
+}
+
+// EncryptedMnemonicToPrivateKey converts a mnemonic seed protected by
+// passphrase back to the private key. There's no way to tell if the
+// passphrase is right, other than by the public keys of the wallet.
+func EncryptedMnemonicToPrivateKey(mnemonic, passphrase string) (keys.SecretKey, error) {
+	encrypted, err := MnemonicToPrivateKey(mnemonic)
+
+	if err != nil {
+		return keys.SecretKey{}, err
+	}
+
+	return DecryptPrivateKey(encrypted, passphrase)
+}
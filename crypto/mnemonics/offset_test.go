@@ -0,0 +1,120 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// offsetSeed is the key of the official seed protected by a passphrase,
+// the key written in the seed and the seed itself
+var offsetSeed = struct {
+	passphrase string
+	encrypted  string
+	mnemonic   string
+}{
+	passphrase: "turtle",
+	encrypted:  "b02cebe7c0d1a73cf6920abdab99fc95dc3f0c7ca610b64d69c9e0f2f932b808",
+	mnemonic:   "sample ivory giddy italics gels needed howls yesterday roared ourselves hurried worry ability cunning piano certain fancy noted asked fishing eleven tusks down eluded tusks",
+}
+
+func TestEncryptPrivateKey(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+	want := fromHex(t, offsetSeed.encrypted)
+
+	// the key written in the seed is key + Hs(passphrase)
+	offset := keys.HashToScalar([]byte(offsetSeed.passphrase))
+
+	var sum keys.SecretKey
+
+	ed25519.ScAdd((*[32]byte)(&sum), (*[32]byte)(&key), (*[32]byte)(&offset))
+
+	if sum != want {
+		t.Fatalf("key + Hs(%q) = %v, want %v", offsetSeed.passphrase, sum, want)
+	}
+
+	if encrypted, err := EncryptPrivateKey(key, offsetSeed.passphrase); err != nil || encrypted != want {
+		t.Errorf("EncryptPrivateKey = %v, %v, want %v", encrypted, err, want)
+	}
+
+	if decrypted, err := DecryptPrivateKey(want, offsetSeed.passphrase); err != nil || decrypted != key {
+		t.Errorf("DecryptPrivateKey = %v, %v, want %v", decrypted, err, key)
+	}
+
+	// an empty passphrase leaves the key as it is
+	if encrypted, err := EncryptPrivateKey(key, ""); err != nil || encrypted != key {
+		t.Errorf("EncryptPrivateKey with no passphrase = %v, %v, want %v", encrypted, err, key)
+	}
+
+	if decrypted, err := DecryptPrivateKey(key, ""); err != nil || decrypted != key {
+		t.Errorf("DecryptPrivateKey with no passphrase = %v, %v, want %v", decrypted, err, key)
+	}
+
+	unreduced := fromHex(t, "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+
+	if _, err := EncryptPrivateKey(unreduced, offsetSeed.passphrase); err != keys.ErrInvalidSecretKey {
+		t.Errorf("EncryptPrivateKey: err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+
+	if _, err := DecryptPrivateKey(unreduced, offsetSeed.passphrase); err != keys.ErrInvalidSecretKey {
+		t.Errorf("DecryptPrivateKey: err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+}
+
+// TestPassphraseNormalisation checks a passphrase gives the same key
+// however its accented letters are typed
+func TestPassphraseNormalisation(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+
+	precomposed, err := EncryptPrivateKey(key, "tortue \u00e9t\u00e9")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decomposed, err := EncryptPrivateKey(key, "tortue e\u0301te\u0301"); err != nil || decomposed != precomposed {
+		t.Errorf("decomposed passphrase gives %v, %v, want %v", decomposed, err, precomposed)
+	}
+
+	if other, _ := EncryptPrivateKey(key, "tortue ete"); other == precomposed {
+		t.Error("passphrase without the accents gives the same key")
+	}
+}
+
+func TestEncryptedMnemonic(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+
+	if mnemonic, err := PrivateKeyToEncryptedMnemonic(key, offsetSeed.passphrase); err != nil || mnemonic != offsetSeed.mnemonic {
+		t.Errorf("PrivateKeyToEncryptedMnemonic = %q, %v, want %q", mnemonic, err, offsetSeed.mnemonic)
+	}
+
+	if mnemonic, err := PrivateKeyToEncryptedMnemonic(key, ""); err != nil || mnemonic != officialSeed.mnemonic {
+		t.Errorf("PrivateKeyToEncryptedMnemonic with no passphrase = %q, %v, want %q", mnemonic, err, officialSeed.mnemonic)
+	}
+
+	if res, err := EncryptedMnemonicToPrivateKey(offsetSeed.mnemonic, offsetSeed.passphrase); err != nil || res != key {
+		t.Errorf("EncryptedMnemonicToPrivateKey = %v, %v, want %v", res, err, key)
+	}
+
+	// a wrong passphrase gives another valid key
+	if res, err := EncryptedMnemonicToPrivateKey(offsetSeed.mnemonic, "Turtle"); err != nil || res == key {
+		t.Errorf("wrong passphrase: EncryptedMnemonicToPrivateKey = %v, %v", res, err)
+	}
+
+	if _, err := EncryptedMnemonicToPrivateKey(withWord(24, "ability"), offsetSeed.passphrase); err != ErrBadChecksum {
+		t.Errorf("bad checksum: err = %v, want %v", err, ErrBadChecksum)
+	}
+
+	if _, err := PrivateKeyToEncryptedMnemonic(fromHex(t, "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010"), offsetSeed.passphrase); err != keys.ErrInvalidSecretKey {
+		t.Errorf("PrivateKeyToEncryptedMnemonic: err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+}
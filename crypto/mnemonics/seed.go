@@ -0,0 +1,134 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keccak"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// The 25 word seeds only hold the private key, so restoring a wallet from
+// one means scanning the chain from the first block. The 31 word seeds
+// also record the height the wallet was created at, so a restore can start
+// from there. They encode 40 bytes, the same way as the key in a 25 word
+// seed, followed by a checksum word:
+//
+//	key (32) || version (1) || height (4, little endian) || checksum (3)
+//
+// where the checksum is the start of the keccak hash of the first 37
+// bytes. It catches the mistakes the checksum word can't, such as two
+// words being swapped.
+
+const (
+	// LegacySeedVersion is the version of 25 word seeds, which have no
+	// height
+	LegacySeedVersion = 0
+	// SeedVersion is the version of the 31 word seeds written by
+	// SeedToMnemonic
+	SeedVersion = 1
+)
+
+// legacyWords and seedWords are the number of words in each format
+const (
+	legacyWords = 25
+	seedWords   = 31
+)
+
+// ErrUnsupportedVersion is returned when a 31 word seed has a version this
+// package doesn't know
+var ErrUnsupportedVersion = errors.New("mnemonic seed version is not supported")
+
+// Seed is the contents of a mnemonic seed
+type Seed struct {
+	// Key is the private spend key of the wallet
+	Key keys.SecretKey
+	// Version is the version of the seed format
+	Version byte
+	// Height is the height of the chain when the wallet was created, 0 for
+	// 25 word seeds
+	Height uint32
+}
+
+// SeedToMnemonic converts a private key and the height the wallet was
+// created at to a 31 word English mnemonic seed, see
+// Language.SeedToMnemonic
+func SeedToMnemonic(key keys.SecretKey, height uint32) (string, error) {
+	return English.SeedToMnemonic(key, height)
+}
+
+// SeedToMnemonic converts a private key and the height the wallet was
+// created at to a 31 word mnemonic seed. The 25 word seeds of
+// PrivateKeyToMnemonic are still needed by wallets which don't read
+// this format.
+func (l *Language) SeedToMnemonic(key keys.SecretKey, height uint32) (string, error) {
+	if !ed25519.ScCheck((*[32]byte)(&key)) {
+		return "", keys.ErrInvalidSecretKey
+	}
+
+	data := make([]byte, 40)
+
+	copy(data, key[:])
+
+	data[32] = SeedVersion
+
+	binary.LittleEndian.PutUint32(data[33:37], height)
+
+	copy(data[37:], keccak.Keccak(data[:37], 32))
+
+	return l.bytesToMnemonic(data), nil
+}
+
+// MnemonicToSeed converts a 25 or 31 word mnemonic seed back to the
+// private key and, for 31 word seeds, the height the wallet was created
+// at. See MnemonicToPrivateKey for how the words are read.
+func MnemonicToSeed(mnemonic string) (Seed, error) {
+	l, w, err := mnemonicToIndexes(mnemonic, ErrWrongLength, legacyWords, seedWords)
+
+	if err != nil {
+		return Seed{}, err
+	}
+
+	return l.indexesToSeed(w)
+}
+
+// indexesToSeed decodes and checks the seed with the given word indexes
+func (l *Language) indexesToSeed(w []int) (Seed, error) {
+	var seed Seed
+
+	data, err := l.indexesToBytes(w)
+
+	if err != nil {
+		return Seed{}, err
+	}
+
+	copy(seed.Key[:], data)
+
+	if len(w) == seedWords {
+		if string(keccak.Keccak(data[:37], 32)[:3]) != string(data[37:]) {
+			return Seed{}, ErrBadChecksum
+		}
+
+		if data[32] != SeedVersion {
+			return Seed{}, ErrUnsupportedVersion
+		}
+
+		seed.Version = data[32]
+		seed.Height = binary.LittleEndian.Uint32(data[33:37])
+	}
+
+	if !ed25519.ScCheck((*[32]byte)(&seed.Key)) {
+		return Seed{}, ErrInvalidKey
+	}
+
+	return seed, nil
+}
@@ -0,0 +1,161 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/keccak"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// heightSeed is the 31 word seed of the official seed's key created at
+// height 1234567. The first 24 words are those of the 25 word seed.
+var heightSeed = struct {
+	height   uint32
+	mnemonic string
+}{
+	height:   1234567,
+	mnemonic: "wiggle drowning auburn aquarium attire meant impel phase soothe heron android mechanic inroads energy smog niece enforce syllabus exquisite lush bluntly rage siblings soda cylinder request slug cynical fugitive taxi wiggle",
+}
+
+func TestSeedToMnemonic(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+
+	mnemonic, err := SeedToMnemonic(key, heightSeed.height)
+
+	if err != nil || mnemonic != heightSeed.mnemonic {
+		t.Fatalf("SeedToMnemonic = %q, %v, want %q", mnemonic, err, heightSeed.mnemonic)
+	}
+
+	words := strings.Fields(mnemonic)
+
+	if strings.Join(words[:24], " ") != strings.Join(strings.Fields(officialSeed.mnemonic)[:24], " ") {
+		t.Error("the key is not encoded as in the 25 word seed")
+	}
+
+	// words 25 to 30 hold the version, the height and the check bytes
+	w := make([]int, len(words))
+
+	for i, word := range words {
+		w[i] = English.indexes[word]
+	}
+
+	data, err := English.indexesToBytes(w)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{SeedVersion, 0, 0, 0, 0}
+
+	binary.LittleEndian.PutUint32(want[1:], heightSeed.height)
+
+	want = append(want, keccak.Keccak(data[:37], 32)[:3]...)
+
+	if !bytes.Equal(data[32:], want) {
+		t.Errorf("seed ends with %x, want %x", data[32:], want)
+	}
+
+	if _, err := SeedToMnemonic(fromHex(t, "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010"), 1); err != keys.ErrInvalidSecretKey {
+		t.Errorf("err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+}
+
+func TestMnemonicToSeed(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+
+	for _, test := range []struct {
+		mnemonic string
+		want     Seed
+	}{
+		{heightSeed.mnemonic, Seed{Key: key, Version: SeedVersion, Height: heightSeed.height}},
+		{officialSeed.mnemonic, Seed{Key: key, Version: LegacySeedVersion}},
+	} {
+		if seed, err := MnemonicToSeed(test.mnemonic); err != nil || seed != test.want {
+			t.Errorf("MnemonicToSeed(%q) = %+v, %v, want %+v", test.mnemonic, seed, err, test.want)
+		}
+	}
+
+	if res, err := MnemonicToPrivateKey(heightSeed.mnemonic); err != nil || res != key {
+		t.Errorf("MnemonicToPrivateKey of a 31 word seed = %v, %v, want %v", res, err, key)
+	}
+
+	for _, height := range []uint32{0, 1, 0xffffffff} {
+		mnemonic, err := SeedToMnemonic(key, height)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if seed, err := MnemonicToSeed(mnemonic); err != nil || seed.Height != height || seed.Key != key {
+			t.Errorf("height %d: MnemonicToSeed = %+v, %v", height, seed, err)
+		}
+	}
+}
+
+// seedWith encodes the 40 bytes of a 31 word seed, with check bytes that
+// match unless badCheck is set
+func seedWith(key keys.SecretKey, version byte, badCheck bool) string {
+	data := make([]byte, 40)
+
+	copy(data, key[:])
+
+	data[32] = version
+
+	copy(data[37:], keccak.Keccak(data[:37], 32))
+
+	if badCheck {
+		data[39] ^= 1
+	}
+
+	return English.bytesToMnemonic(data)
+}
+
+func TestMnemonicToSeedErrors(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+	words := strings.Fields(heightSeed.mnemonic)
+
+	for _, mnemonic := range []string{
+		strings.Join(words[:30], " "),
+		heightSeed.mnemonic + " " + words[0],
+	} {
+		if _, err := MnemonicToSeed(mnemonic); err != ErrWrongLength {
+			t.Errorf("%d words: err = %v, want %v", len(strings.Fields(mnemonic)), err, ErrWrongLength)
+		}
+	}
+
+	// two words swapped
+	swapped := append([]string(nil), words...)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+
+	if _, err := MnemonicToSeed(strings.Join(swapped, " ")); err != ErrBadChecksum {
+		t.Errorf("swapped words: err = %v, want %v", err, ErrBadChecksum)
+	}
+
+	if _, err := MnemonicToSeed(seedWith(key, SeedVersion, true)); err != ErrBadChecksum {
+		t.Errorf("bad check bytes: err = %v, want %v", err, ErrBadChecksum)
+	}
+
+	if _, err := MnemonicToSeed(seedWith(key, SeedVersion+1, false)); err != ErrUnsupportedVersion {
+		t.Errorf("version %d: err = %v, want %v", SeedVersion+1, err, ErrUnsupportedVersion)
+	}
+
+	if _, err := MnemonicToSeed(seedWith(fromHex(t, "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010"), SeedVersion, false)); err != ErrInvalidKey {
+		t.Errorf("key not reduced: err = %v, want %v", err, ErrInvalidKey)
+	}
+
+	if _, err := MnemonicToSeed(strings.Join(append(words[:30:30], "qqqq"), " ")); !reflect.DeepEqual(err, &UnknownWordError{Position: 31, Word: "qqqq"}) {
+		t.Errorf("unknown word: err = %v, want word 31 (qqqq)", err)
+	}
+}
@@ -0,0 +1,253 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// A private key can be split into n shares, any threshold of which give
+// back the key, while fewer give nothing away. Each byte of the key is the
+// constant term of a random polynomial over GF(256) of degree
+// threshold - 1, and share i holds the value of every polynomial at x = i.
+//
+// Each share is written as a 28 word mnemonic, encoding 36 bytes the same
+// way as the key in a 25 word seed, followed by a checksum word:
+//
+//	value (32) || threshold (1) || index (1) || id (2, little endian)
+//
+// The id is random and is the same for every share of a split, so shares
+// of different splits aren't combined by mistake.
+
+// shareWords is the number of words in a share
+const shareWords = 28
+
+// MaxShares is the largest number of shares a key can be split into
+const MaxShares = 255
+
+// ErrWrongShareLength is returned when a mnemonic share isn't 28 words
+// long
+var ErrWrongShareLength = errors.New("mnemonic share must be 28 words long")
+
+// ErrInvalidThreshold is returned when the number of shares needed is not
+// between 1 and the number of shares, or there are too many shares
+var ErrInvalidThreshold = errors.New("threshold must be between 1 and the number of shares")
+
+// ErrInvalidShare is returned when a share has an index of 0, or a
+// threshold of 0
+var ErrInvalidShare = errors.New("mnemonic share is not valid")
+
+// ErrNotEnoughShares is returned when fewer shares are given than the
+// threshold they were split with
+var ErrNotEnoughShares = errors.New("not enough mnemonic shares")
+
+// ErrMismatchedShares is returned when shares are from different splits
+var ErrMismatchedShares = errors.New("mnemonic shares are from different splits")
+
+// ErrDuplicateShare is returned when the same share is given twice
+var ErrDuplicateShare = errors.New("mnemonic share is given more than once")
+
+// Share is the contents of a mnemonic share
+type Share struct {
+	// ID is the same for every share of a split
+	ID uint16
+	// Threshold is the number of shares needed to get the key back
+	Threshold byte
+	// Index is the x coordinate of the share, from 1
+	Index byte
+	// Value is the value of the polynomials at Index
+	Value [32]byte
+}
+
+// gfMul multiplies in GF(256) with the polynomial x^8 + x^4 + x^3 + x + 1,
+// without branching on the values
+func gfMul(a, b byte) byte {
+	var res byte
+
+	for i := 0; i < 8; i++ {
+		res ^= -(b & 1) & a
+		b >>= 1
+		a = a<<1 ^ -(a>>7)&0x1b
+	}
+
+	return res
+}
+
+// gfInv returns the inverse of a in GF(256), a^254, or 0 if a is 0
+func gfInv(a byte) byte {
+	res := byte(1)
+
+	for i := 0; i < 7; i++ {
+		a = gfMul(a, a)
+		res = gfMul(res, a)
+	}
+
+	return res
+}
+
+// SplitPrivateKey splits a private key into English mnemonic shares, see
+// Language.SplitPrivateKey
+func SplitPrivateKey(key keys.SecretKey, threshold, shares int) ([]string, error) {
+	return English.SplitPrivateKey(key, threshold, shares)
+}
+
+// SplitPrivateKey splits a private key into shares mnemonic shares, any
+// threshold of which can be combined with CombineShares to get the key
+// back
+func (l *Language) SplitPrivateKey(key keys.SecretKey, threshold, shares int) ([]string, error) {
+	if !ed25519.ScCheck((*[32]byte)(&key)) {
+		return nil, keys.ErrInvalidSecretKey
+	}
+
+	if threshold < 1 || threshold > shares || shares > MaxShares {
+		return nil, ErrInvalidThreshold
+	}
+
+	// coefficients[i] holds the coefficient of x^(i+1) for every byte
+	coefficients := make([]byte, 32*(threshold-1)+2)
+
+	if _, err := io.ReadFull(rand.Reader, coefficients); err != nil {
+		return nil, err
+	}
+
+	id := binary.LittleEndian.Uint16(coefficients[len(coefficients)-2:])
+
+	res := make([]string, shares)
+
+	for i := range res {
+		share := Share{
+			ID:        id,
+			Threshold: byte(threshold),
+			Index:     byte(i + 1),
+		}
+
+		for j := range key {
+			// Horner's method, from the highest coefficient down
+			var y byte
+
+			for k := threshold - 2; k >= 0; k-- {
+				y = gfMul(y, share.Index) ^ coefficients[32*k+j]
+			}
+
+			share.Value[j] = gfMul(y, share.Index) ^ key[j]
+		}
+
+		res[i] = l.shareToMnemonic(&share)
+	}
+
+	return res, nil
+}
+
+// shareToMnemonic encodes a share as words
+func (l *Language) shareToMnemonic(share *Share) string {
+	data := make([]byte, 36)
+
+	copy(data, share.Value[:])
+
+	data[32] = share.Threshold
+	data[33] = share.Index
+
+	binary.LittleEndian.PutUint16(data[34:], share.ID)
+
+	return l.bytesToMnemonic(data)
+}
+
+// MnemonicToShare reads a 28 word mnemonic share, checking the words and
+// the checksum
+func MnemonicToShare(mnemonic string) (Share, error) {
+	var share Share
+
+	l, w, err := mnemonicToIndexes(mnemonic, ErrWrongShareLength, shareWords)
+
+	if err != nil {
+		return share, err
+	}
+
+	data, err := l.indexesToBytes(w)
+
+	if err != nil {
+		return share, err
+	}
+
+	copy(share.Value[:], data)
+
+	share.Threshold = data[32]
+	share.Index = data[33]
+	share.ID = binary.LittleEndian.Uint16(data[34:])
+
+	if share.Threshold == 0 || share.Index == 0 {
+		return Share{}, ErrInvalidShare
+	}
+
+	return share, nil
+}
+
+// CombineShares gets back the private key from at least threshold of the
+// mnemonic shares it was split into. Only the first threshold shares are
+// used. Shares which have been mixed up with the right number of shares
+// of another key with the same id can't be detected, and usually give an
+// invalid key.
+func CombineShares(mnemonics []string) (keys.SecretKey, error) {
+	var key keys.SecretKey
+
+	shares := make([]Share, len(mnemonics))
+
+	for i, mnemonic := range mnemonics {
+		share, err := MnemonicToShare(mnemonic)
+
+		if err != nil {
+			return key, err
+		}
+
+		if i > 0 && (share.ID != shares[0].ID || share.Threshold != shares[0].Threshold) {
+			return key, ErrMismatchedShares
+		}
+
+		for _, other := range shares[:i] {
+			if share.Index == other.Index {
+				return key, ErrDuplicateShare
+			}
+		}
+
+		shares[i] = share
+	}
+
+	if len(shares) == 0 || len(shares) < int(shares[0].Threshold) {
+		return key, ErrNotEnoughShares
+	}
+
+	shares = shares[:shares[0].Threshold]
+
+	// Lagrange interpolation at x = 0, where subtraction is xor
+	for i, share := range shares {
+		basis := byte(1)
+
+		for j, other := range shares {
+			if i != j {
+				basis = gfMul(basis, gfMul(other.Index, gfInv(other.Index^share.Index)))
+			}
+		}
+
+		for j := range key {
+			key[j] ^= gfMul(basis, share.Value[j])
+		}
+	}
+
+	if !ed25519.ScCheck((*[32]byte)(&key)) {
+		return keys.SecretKey{}, ErrInvalidKey
+	}
+
+	return key, nil
+}
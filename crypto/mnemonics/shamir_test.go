@@ -0,0 +1,208 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+func TestGF256(t *testing.T) {
+	// the examples of section 4.2 of FIPS 197, which uses the same field
+	for _, test := range []struct{ a, b, want byte }{
+		{0x57, 0x83, 0xc1},
+		{0x57, 0x13, 0xfe},
+		{0x57, 0x02, 0xae},
+		{0x57, 0x01, 0x57},
+		{0x57, 0x00, 0x00},
+	} {
+		if res := gfMul(test.a, test.b); res != test.want {
+			t.Errorf("gfMul(%#x, %#x) = %#x, want %#x", test.a, test.b, res, test.want)
+		}
+	}
+
+	if res := gfInv(0x53); res != 0xca {
+		t.Errorf("gfInv(0x53) = %#x, want 0xca", res)
+	}
+
+	if res := gfInv(0); res != 0 {
+		t.Errorf("gfInv(0) = %#x, want 0", res)
+	}
+
+	for a := 1; a < 256; a++ {
+		if res := gfMul(byte(a), gfInv(byte(a))); res != 1 {
+			t.Errorf("%#x * gfInv(%#x) = %#x, want 1", a, a, res)
+		}
+	}
+}
+
+// knownShares returns the shares of a 2 of 3 split of the official seed's
+// key where every byte has the polynomial key + 0x57 x, so share x holds
+// key xor 0x57, key xor 0xae and key xor 0xf9 for x = 1, 2 and 3
+func knownShares(t *testing.T) []string {
+	t.Helper()
+
+	key := fromHex(t, officialSeed.key)
+
+	var res []string
+
+	for i, c := range []byte{0x57, 0xae, 0xf9} {
+		share := Share{ID: 0x1234, Threshold: 2, Index: byte(i + 1)}
+
+		for j := range share.Value {
+			share.Value[j] = key[j] ^ c
+		}
+
+		res = append(res, English.shareToMnemonic(&share))
+	}
+
+	return res
+}
+
+func TestCombineShares(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+	shares := knownShares(t)
+
+	share, err := MnemonicToShare(shares[1])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if share.ID != 0x1234 || share.Threshold != 2 || share.Index != 2 || share.Value[0] != key[0]^0xae {
+		t.Errorf("MnemonicToShare = %+v", share)
+	}
+
+	for _, pair := range [][]string{
+		{shares[0], shares[1]},
+		{shares[1], shares[0]},
+		{shares[0], shares[2]},
+		{shares[2], shares[1]},
+		shares,
+	} {
+		if res, err := CombineShares(pair); err != nil || res != key {
+			t.Errorf("CombineShares = %v, %v, want %v", res, err, key)
+		}
+	}
+}
+
+func TestSplitPrivateKey(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+
+	for _, size := range []struct{ threshold, shares int }{{1, 1}, {1, 3}, {2, 2}, {3, 5}, {5, 5}} {
+		shares, err := SplitPrivateKey(key, size.threshold, size.shares)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(shares) != size.shares {
+			t.Fatalf("%d of %d: %d shares", size.threshold, size.shares, len(shares))
+		}
+
+		for _, share := range shares {
+			if n := len(strings.Fields(share)); n != shareWords {
+				t.Fatalf("%d of %d: share of %d words", size.threshold, size.shares, n)
+			}
+		}
+
+		// every run of threshold shares, from each starting share
+		for start := range shares {
+			var subset []string
+
+			for i := 0; i < size.threshold; i++ {
+				subset = append(subset, shares[(start+i)%len(shares)])
+			}
+
+			if res, err := CombineShares(subset); err != nil || res != key {
+				t.Errorf("%d of %d, from share %d: CombineShares = %v, %v, want %v", size.threshold, size.shares, start+1, res, err, key)
+			}
+		}
+
+		if size.threshold > 1 {
+			if _, err := CombineShares(shares[:size.threshold-1]); err != ErrNotEnoughShares {
+				t.Errorf("%d of %d: err = %v, want %v", size.threshold, size.shares, err, ErrNotEnoughShares)
+			}
+		}
+	}
+}
+
+func TestSplitPrivateKeyErrors(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+
+	for _, size := range []struct{ threshold, shares int }{{0, 3}, {-1, 3}, {4, 3}, {2, MaxShares + 1}} {
+		if _, err := SplitPrivateKey(key, size.threshold, size.shares); err != ErrInvalidThreshold {
+			t.Errorf("%d of %d: err = %v, want %v", size.threshold, size.shares, err, ErrInvalidThreshold)
+		}
+	}
+
+	if _, err := SplitPrivateKey(fromHex(t, "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010"), 2, 3); err != keys.ErrInvalidSecretKey {
+		t.Errorf("err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+}
+
+func TestCombineSharesErrors(t *testing.T) {
+	key := fromHex(t, officialSeed.key)
+	shares := knownShares(t)
+
+	other, err := SplitPrivateKey(key, 2, 3)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		shares []string
+		want   error
+	}{
+		{"no shares", nil, ErrNotEnoughShares},
+		{"one share", shares[:1], ErrNotEnoughShares},
+		{"same share twice", []string{shares[0], shares[0]}, ErrDuplicateShare},
+		{"another split", []string{shares[0], other[1]}, ErrMismatchedShares},
+		{"25 words", []string{shares[0], officialSeed.mnemonic}, ErrWrongShareLength},
+	} {
+		if _, err := CombineShares(test.shares); err != test.want {
+			t.Errorf("%s: err = %v, want %v", test.name, err, test.want)
+		}
+	}
+
+	words := strings.Fields(shares[1])
+	last := len(words) - 1
+	words[last] = English.Words()[(English.indexes[words[last]]+1)%WordListLength]
+
+	if _, err := CombineShares([]string{shares[0], strings.Join(words, " ")}); err != ErrBadChecksum {
+		t.Errorf("bad checksum: err = %v, want %v", err, ErrBadChecksum)
+	}
+
+	for _, share := range []Share{{ID: 1, Threshold: 2, Index: 0}, {ID: 1, Threshold: 0, Index: 1}} {
+		if _, err := MnemonicToShare(English.shareToMnemonic(&share)); err != ErrInvalidShare {
+			t.Errorf("threshold %d, index %d: err = %v, want %v", share.Threshold, share.Index, err, ErrInvalidShare)
+		}
+	}
+
+	// shares of a value which isn't a reduced key
+	var unreduced []string
+
+	for i := 1; i <= 2; i++ {
+		share := Share{ID: 1, Threshold: 2, Index: byte(i)}
+
+		for j := range share.Value {
+			share.Value[j] = 0xff ^ gfMul(0x57, byte(i))
+		}
+
+		unreduced = append(unreduced, English.shareToMnemonic(&share))
+	}
+
+	if _, err := CombineShares(unreduced); err != ErrInvalidKey {
+		t.Errorf("key not reduced: err = %v, want %v", err, ErrInvalidKey)
+	}
+}
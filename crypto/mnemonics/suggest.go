@@ -0,0 +1,279 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownWord is returned when a word doesn't match any in the list
+var ErrUnknownWord = errors.New("word is not in the word list")
+
+// ErrAmbiguousWord is returned when a word is the start of more than one
+// word in the list
+var ErrAmbiguousWord = errors.New("word is the start of more than one word")
+
+// Correction is a replacement for one word of a mnemonic seed
+type Correction struct {
+	// Position is the position of the word in the seed, starting at 1
+	Position int
+	// Word is the word to use instead
+	Word string
+	// Distance is the edit distance between the replaced word and Word
+	Distance int
+}
+
+// resolveWord returns the index of the word in the list that word stands
+// for, see ResolveWord
+func (l *Language) resolveWord(word string) (int, error) {
+	start := normalise(prefix(word, l.PrefixLength))
+	word = normalise(word)
+
+	if index, ok := l.indexes[word]; ok {
+		return index, nil
+	}
+
+	matches := l.autocomplete(word)
+
+	if len(matches) > 1 {
+		return 0, ErrAmbiguousWord
+	}
+
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	// the wallets only look at the first letters of each word, which
+	// are unique within the list
+	if index, ok := l.prefixes[start]; ok {
+		return index, nil
+	}
+
+	return 0, ErrUnknownWord
+}
+
+// ResolveWord returns the word in the English list that word stands for,
+// see Language.ResolveWord
+func ResolveWord(word string) (string, error) {
+	return English.ResolveWord(word)
+}
+
+// ResolveWord returns the word in the list that word stands for. It is
+// either the word itself, the start of exactly one word, or a word which
+// shares the first PrefixLength letters with one, which is all the
+// wallets read.
+func (l *Language) ResolveWord(word string) (string, error) {
+	index, err := l.resolveWord(word)
+
+	if err != nil {
+		return "", err
+	}
+
+	return l.words[index], nil
+}
+
+// autocomplete returns the indexes of the words which start with the
+// normalised prefix
+func (l *Language) autocomplete(prefix string) []int {
+	var res []int
+
+	for i, word := range l.normalised {
+		if strings.HasPrefix(word, prefix) {
+			res = append(res, i)
+		}
+	}
+
+	return res
+}
+
+// Autocomplete returns the words in the English list which start with
+// prefix, see Language.Autocomplete
+func Autocomplete(prefix string) []string {
+	return English.Autocomplete(prefix)
+}
+
+// Autocomplete returns the words in the list which start with prefix, in
+// the order of the list
+func (l *Language) Autocomplete(prefix string) []string {
+	var res []string
+
+	for _, index := range l.autocomplete(normalise(prefix)) {
+		res = append(res, l.words[index])
+	}
+
+	return res
+}
+
+// levenshtein returns the edit distance between a and b, the number of
+// single letter insertions, deletions and substitutions to turn a into b
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	prev := make([]int, len(t)+1)
+	cur := make([]int, len(t)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := range s {
+		cur[0] = i + 1
+
+		for j := range t {
+			cost := 1
+
+			if s[i] == t[j] {
+				cost = 0
+			}
+
+			cur[j+1] = min3(prev[j+1]+1, cur[j]+1, prev[j]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(t)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+
+	if c < a {
+		a = c
+	}
+
+	return a
+}
+
+// Suggest returns up to max words from the English list closest to word,
+// see Language.Suggest
+func Suggest(word string, max int) []string {
+	return English.Suggest(word, max)
+}
+
+// Suggest returns up to max words from the list closest to word by edit
+// distance, closest first, or every word if max is negative. Words at the
+// same distance are in the order of the list.
+func (l *Language) Suggest(word string, max int) []string {
+	word = normalise(word)
+
+	distances := make([]int, len(l.words))
+	order := make([]int, len(l.words))
+
+	for i, candidate := range l.normalised {
+		distances[i] = levenshtein(word, candidate)
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return distances[order[i]] < distances[order[j]]
+	})
+
+	if max >= 0 && max < len(order) {
+		order = order[:max]
+	}
+
+	res := make([]string, len(order))
+
+	for i, index := range order {
+		res[i] = l.words[index]
+	}
+
+	return res
+}
+
+// FindWrongWord looks for a single word which can be replaced to make the
+// seed mnemonic valid, when MnemonicToPrivateKey fails because of the
+// checksum or because the words don't give a valid key. It returns up to max corrections, or all of them if max is
+// negative, the most likely first, which are those closest by edit
+// distance to the word they replace. If a word isn't in the list, it must
+// be the wrong one. It returns nil if the seed is already valid.
+//
+// The checksum word of a 25 word seed is one of 24, so roughly 1 in 24
+// replacements give a valid seed. Choose between the corrections by the
+// public keys they give. The checksum of 31 word seeds rules out nearly
+// all of them.
+func FindWrongWord(mnemonic string, max int) ([]Correction, error) {
+	words := strings.Fields(mnemonic)
+
+	if len(words) != legacyWords && len(words) != seedWords {
+		return nil, ErrWrongLength
+	}
+
+	l, _ := detectLanguage(words)
+
+	w := make([]int, len(words))
+	unknown := -1
+
+	for i, word := range words {
+		index, err := l.resolveWord(word)
+
+		if err == nil {
+			w[i] = index
+			continue
+		}
+
+		if unknown >= 0 {
+			return nil, &UnknownWordError{Position: i + 1, Word: word}
+		}
+
+		unknown = i
+	}
+
+	if unknown < 0 {
+		// a typo in the last words often gives a key which isn't
+		// reduced, rather than a bad checksum
+		if _, err := l.indexesToSeed(w); err != ErrBadChecksum && err != ErrInvalidKey {
+			return nil, err
+		}
+	}
+
+	var res []Correction
+
+	for i := range w {
+		if unknown >= 0 && i != unknown {
+			continue
+		}
+
+		original := w[i]
+		typed := normalise(words[i])
+
+		for j, candidate := range l.words {
+			if j == original && unknown < 0 {
+				continue
+			}
+
+			w[i] = j
+
+			if _, err := l.indexesToSeed(w); err == nil {
+				res = append(res, Correction{
+					Position: i + 1,
+					Word:     candidate,
+					Distance: levenshtein(typed, l.normalised[j]),
+				})
+			}
+		}
+
+		w[i] = original
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Distance < res[j].Distance
+	})
+
+	if max >= 0 && max < len(res) {
+		res = res[:max]
+	}
+
+	return res, nil
+}
@@ -0,0 +1,141 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package mnemonics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// newMnemonic returns the English mnemonic of a random key
+func newMnemonic(t *testing.T) []string {
+	t.Helper()
+
+	_, secret, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mnemonic, err := PrivateKeyToMnemonic(secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.Fields(mnemonic)
+}
+
+func TestSuggest(t *testing.T) {
+	for _, test := range []struct {
+		max  int
+		want int
+	}{
+		{-1, WordListLength},
+		{0, 0},
+		{3, 3},
+		{WordListLength + 1, WordListLength},
+	} {
+		res := Suggest("turtle", test.max)
+
+		if len(res) != test.want {
+			t.Errorf("Suggest with max %d: %d words, want %d", test.max, len(res), test.want)
+		}
+	}
+
+	if res := Suggest(English.Words()[42], 1); len(res) != 1 || res[0] != English.Words()[42] {
+		t.Errorf("Suggest of a word in the list = %v, want the word", res)
+	}
+}
+
+func TestFindWrongWord(t *testing.T) {
+	words := newMnemonic(t)
+
+	if res, err := FindWrongWord(strings.Join(words, " "), -1); res != nil || err != nil {
+		t.Fatalf("valid seed: got %v, %v, want no corrections", res, err)
+	}
+
+	original := words[2]
+
+	// a word which isn't in the list, and a word which is but breaks the
+	// checksum
+	for _, typo := range []string{"zzzzzz", English.Words()[(English.indexes[original]+1)%WordListLength]} {
+		words[2] = typo
+
+		mnemonic := strings.Join(words, " ")
+
+		for _, max := range []int{-1, 0, 1, 1000} {
+			res, err := FindWrongWord(mnemonic, max)
+
+			if err != nil {
+				t.Fatalf("%q with max %d: %v", typo, max, err)
+			}
+
+			if max >= 0 && len(res) > max {
+				t.Errorf("%q with max %d: %d corrections", typo, max, len(res))
+			}
+
+			if max != -1 {
+				continue
+			}
+
+			found := false
+
+			for _, correction := range res {
+				found = found || correction == Correction{Position: 3, Word: original, Distance: levenshtein(typo, original)}
+			}
+
+			if !found {
+				t.Errorf("%q: corrections %v don't include %q at position 3", typo, res, original)
+			}
+		}
+	}
+}
+
+// TestFindWrongWordInvalidKey checks corrections are found for a typo in
+// the words holding the last bytes of the key, which gives a key that
+// isn't reduced rather than a bad checksum
+func TestFindWrongWordInvalidKey(t *testing.T) {
+	for _, position := range []int{23, 24} {
+		words := strings.Fields(officialSeed.mnemonic)
+		original := words[position-1]
+		typo := ""
+
+		for _, candidate := range English.Words() {
+			words[position-1] = candidate
+
+			if _, err := MnemonicToPrivateKey(strings.Join(words, " ")); err == ErrInvalidKey {
+				typo = candidate
+				break
+			}
+		}
+
+		if typo == "" {
+			t.Fatalf("position %d: no word gives an invalid key", position)
+		}
+
+		res, err := FindWrongWord(strings.Join(words, " "), -1)
+
+		if err != nil {
+			t.Fatalf("%q at position %d: %v", typo, position, err)
+		}
+
+		found := false
+
+		for _, correction := range res {
+			found = found || correction == Correction{Position: position, Word: original, Distance: levenshtein(typo, original)}
+		}
+
+		if !found {
+			t.Errorf("%q at position %d: corrections %v don't include %q", typo, position, res, original)
+		}
+	}
+}
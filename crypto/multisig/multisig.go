@@ -0,0 +1,485 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+// Package multisig implements m of n multisig wallets. The participants
+// share the secret view key, the secret spend key is the sum of a number
+// of subset keys, each of which is known to every member of a subset of
+// n - m + 1 participants. Any m participants between them know every
+// subset key, any fewer do not.
+//
+// The subset keys are built up over a number of rounds. In the first,
+// every participant publishes their public spend key with the proof from
+// ProveKey, which stops a participant choosing a key which cancels out
+// the keys of the others. In each later round every participant publishes
+// the public keys returned by RoundKeys and passes the keys published by
+// everyone to NextRound. Once the setup is complete, the participants
+// should check they all arrived at the same public spend key.
+package multisig
+
+import (
+	"bytes"
+	"errors"
+	"math/bits"
+	"sort"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keccak"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/crypto/signatures"
+)
+
+// MaxParticipants is the largest number of participants supported
+const MaxParticipants = 16
+
+// ErrInvalidThreshold is returned when the number of signers required
+// is not between 1 and the number of participants
+var ErrInvalidThreshold = errors.New("threshold must be between 1 and the number of participants")
+
+// ErrTooManyParticipants is returned when there are more than
+// MaxParticipants participants
+var ErrTooManyParticipants = errors.New("too many participants")
+
+// ErrDuplicateKey is returned when the same public spend key is given
+// for two participants
+var ErrDuplicateKey = errors.New("participant public keys are not unique")
+
+// ErrNotParticipant is returned when the spend key of a participant is
+// not in the list of participant public keys
+var ErrNotParticipant = errors.New("spend key is not one of the participants")
+
+// ErrInvalidProof is returned when the proof of a public spend key
+// doesn't show its owner knows the secret key
+var ErrInvalidProof = errors.New("participant public key proof is not valid")
+
+// ErrKeyMismatch is returned when the public key published for a subset
+// is not the key the participant calculated for it
+var ErrKeyMismatch = errors.New("published subset key does not match")
+
+// ErrMissingKey is returned when the public key of a subset which is
+// needed was not published
+var ErrMissingKey = errors.New("public key of a subset is missing")
+
+// ErrKeysComplete is returned by NextRound once there are no more rounds
+var ErrKeysComplete = errors.New("multisig keys are already complete")
+
+// ErrKeysIncomplete is returned when the multisig keys are used before
+// every round has been completed
+var ErrKeysIncomplete = errors.New("multisig keys are not complete")
+
+// ErrNotEnoughSigners is returned when the signers can't complete a
+// signature between them
+var ErrNotEnoughSigners = errors.New("not enough signers")
+
+// ErrNotSigner is returned when a participant is not one of the signers
+var ErrNotSigner = errors.New("participant is not one of the signers")
+
+// Subset is a set of participants, bit i is set if participant i is a
+// member. Participants are numbered in the order of their public spend
+// keys, as returned by Participant.PublicKeys.
+type Subset uint32
+
+// NewSubset returns the subset of the given participants
+func NewSubset(indexes ...int) Subset {
+	var s Subset
+
+	for _, i := range indexes {
+		s |= 1 << uint(i)
+	}
+
+	return s
+}
+
+// Contains returns true if participant i is a member of s
+func (s Subset) Contains(i int) bool {
+	return s&(1<<uint(i)) != 0
+}
+
+// Len returns the number of members of s
+func (s Subset) Len() int {
+	return bits.OnesCount32(uint32(s))
+}
+
+// highest returns the highest numbered member of s
+func (s Subset) highest() int {
+	return 31 - bits.LeadingZeros32(uint32(s))
+}
+
+// subsets returns every subset of size members out of n participants,
+// in ascending order
+func subsets(n, size int) []Subset {
+	var res []Subset
+
+	for s := Subset(0); s < 1<<uint(n); s++ {
+		if s.Len() == size {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
+
+// Rounds returns the number of rounds of key exchange needed to set up an
+// m of n wallet, n - m + 1. The first round is the exchange of the public
+// spend keys, which is all an n of n wallet needs, each of the others is
+// a call to NextRound.
+func Rounds(m, n int) (int, error) {
+	if n > MaxParticipants {
+		return 0, ErrTooManyParticipants
+	}
+
+	if m < 1 || m > n {
+		return 0, ErrInvalidThreshold
+	}
+
+	return n - m + 1, nil
+}
+
+// Participant is one of the participants setting up, or using, a
+// multisig wallet
+type Participant struct {
+	threshold  int
+	index      int
+	spend      keys.SecretKey
+	publicKeys []keys.PublicKey
+
+	// size is the number of members of the subsets in secrets
+	size    int
+	secrets map[Subset]keys.SecretKey
+}
+
+// proofHash returns the hash signed by the proof of public
+func proofHash(public keys.PublicKey) keys.Hash {
+	var res keys.Hash
+
+	copy(res[:], keccak.Keccak(append([]byte("multisig key proof"), public[:]...), 32))
+
+	return res
+}
+
+// ProveKey returns a proof that the participant knows spend, the secret
+// key of the public spend key they publish in the first round. It is a
+// signature of the public key by itself.
+func ProveKey(spend keys.SecretKey) (signatures.Signature, error) {
+	public, err := keys.SecretKeyToPublicKey(spend)
+
+	if err != nil {
+		return signatures.Signature{}, err
+	}
+
+	return signatures.GenerateSignature(proofHash(public), public, spend)
+}
+
+// NewParticipant starts the setup of a threshold of len(publicKeys)
+// wallet. spend is the secret spend key of the participant and
+// publicKeys the public spend keys of every participant, including
+// this one, in any order. proofs[i] is the proof from ProveKey published
+// with publicKeys[i].
+func NewParticipant(threshold int, spend keys.SecretKey, publicKeys []keys.PublicKey, proofs []signatures.Signature) (*Participant, error) {
+	if _, err := Rounds(threshold, len(publicKeys)); err != nil {
+		return nil, err
+	}
+
+	if len(proofs) != len(publicKeys) {
+		return nil, ErrInvalidProof
+	}
+
+	for i, key := range publicKeys {
+		if !signatures.CheckSignature(proofHash(key), key, proofs[i]) {
+			return nil, ErrInvalidProof
+		}
+	}
+
+	public, err := keys.SecretKeyToPublicKey(spend)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]keys.PublicKey, len(publicKeys))
+
+	copy(sorted, publicKeys)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	p := &Participant{
+		threshold:  threshold,
+		index:      -1,
+		spend:      spend,
+		publicKeys: sorted,
+		size:       1,
+	}
+
+	for i, key := range sorted {
+		if i > 0 && key == sorted[i-1] {
+			return nil, ErrDuplicateKey
+		}
+
+		if !keys.CheckKey(key) {
+			return nil, keys.ErrInvalidPublicKey
+		}
+
+		if key == public {
+			p.index = i
+		}
+	}
+
+	if p.index < 0 {
+		return nil, ErrNotParticipant
+	}
+
+	p.secrets = map[Subset]keys.SecretKey{NewSubset(p.index): spend}
+
+	return p, nil
+}
+
+// Index returns the number of the participant
+func (p *Participant) Index() int {
+	return p.index
+}
+
+// PublicKeys returns the public spend keys of the participants, in the
+// order they are numbered
+func (p *Participant) PublicKeys() []keys.PublicKey {
+	res := make([]keys.PublicKey, len(p.publicKeys))
+
+	copy(res, p.publicKeys)
+
+	return res
+}
+
+// Complete returns true once every round of the setup has been done
+func (p *Participant) Complete() bool {
+	return p.size == len(p.publicKeys)-p.threshold+1
+}
+
+// RoundKeys returns the public keys of the subset keys known to the
+// participant, which are published to the other participants. Once the
+// setup is complete these are used to calculate the shared public
+// spend key.
+func (p *Participant) RoundKeys() (map[Subset]keys.PublicKey, error) {
+	res := make(map[Subset]keys.PublicKey, len(p.secrets))
+
+	for s, secret := range p.secrets {
+		public, err := keys.SecretKeyToPublicKey(secret)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res[s] = public
+	}
+
+	return res, nil
+}
+
+// SecretKeys returns the subset keys known to the participant
+func (p *Participant) SecretKeys() map[Subset]keys.SecretKey {
+	res := make(map[Subset]keys.SecretKey, len(p.secrets))
+
+	for s, secret := range p.secrets {
+		res[s] = secret
+	}
+
+	return res
+}
+
+// subsetKey returns the public key of subset s, the first round uses the
+// public spend keys
+func (p *Participant) subsetKey(published map[Subset]keys.PublicKey, s Subset) (keys.PublicKey, error) {
+	if p.size == 1 {
+		return p.publicKeys[s.highest()], nil
+	}
+
+	public, ok := published[s]
+
+	if !ok {
+		return keys.PublicKey{}, ErrMissingKey
+	}
+
+	return public, nil
+}
+
+// checkPublished returns ErrKeyMismatch if a key published for a subset
+// the participant knows is not the one it calculated
+func (p *Participant) checkPublished(published map[Subset]keys.PublicKey) error {
+	if p.size == 1 {
+		return nil
+	}
+
+	for s, secret := range p.secrets {
+		public, ok := published[s]
+
+		if !ok {
+			continue
+		}
+
+		own, err := keys.SecretKeyToPublicKey(secret)
+
+		if err != nil {
+			return err
+		}
+
+		if public != own {
+			return ErrKeyMismatch
+		}
+	}
+
+	return nil
+}
+
+// NextRound calculates the keys of the subsets one member larger than
+// the current ones, from the keys published in RoundKeys by every
+// participant. The key of subset T is Hs(8 * a * K), where a is the
+// secret spend key of its highest numbered member and K is the public
+// key of T without that member, so it can be calculated by every member
+// of T and nobody else.
+func (p *Participant) NextRound(published map[Subset]keys.PublicKey) error {
+	if p.Complete() {
+		return ErrKeysComplete
+	}
+
+	if err := p.checkPublished(published); err != nil {
+		return err
+	}
+
+	secrets := make(map[Subset]keys.SecretKey)
+
+	for _, t := range subsets(len(p.publicKeys), p.size+1) {
+		var derivation keys.KeyDerivation
+		var err error
+
+		if !t.Contains(p.index) {
+			continue
+		}
+
+		highest := t.highest()
+		rest := t &^ NewSubset(highest)
+
+		if highest == p.index {
+			public, err := p.subsetKey(published, rest)
+
+			if err != nil {
+				return err
+			}
+
+			derivation, err = keys.GenerateKeyDerivation(public, p.spend)
+		} else {
+			derivation, err = keys.GenerateKeyDerivation(p.publicKeys[highest], p.secrets[rest])
+		}
+
+		if err != nil {
+			return err
+		}
+
+		secrets[t] = keys.SecretKey(keys.HashToScalar(derivation[:]))
+	}
+
+	p.secrets = secrets
+	p.size++
+
+	return nil
+}
+
+// SpendPublicKey returns the public spend key of the wallet, the sum of
+// the public keys of every subset, from the keys published in RoundKeys
+// by every participant once the setup is complete
+func (p *Participant) SpendPublicKey(published map[Subset]keys.PublicKey) (keys.PublicKey, error) {
+	if !p.Complete() {
+		return keys.PublicKey{}, ErrKeysIncomplete
+	}
+
+	if err := p.checkPublished(published); err != nil {
+		return keys.PublicKey{}, err
+	}
+
+	var points []keys.PublicKey
+
+	for _, s := range subsets(len(p.publicKeys), p.size) {
+		public, err := p.subsetKey(published, s)
+
+		if err != nil {
+			return keys.PublicKey{}, err
+		}
+
+		points = append(points, public)
+	}
+
+	return addKeys(points)
+}
+
+// SigningKey returns the part of the secret spend key the participant
+// contributes to a signature made together with signers. Each subset key
+// is used by the lowest numbered signer which knows it, so the parts of
+// the signers add up to the secret spend key.
+func (p *Participant) SigningKey(signers Subset) (keys.SecretKey, error) {
+	var res keys.SecretKey
+
+	if !p.Complete() {
+		return res, ErrKeysIncomplete
+	}
+
+	if !signers.Contains(p.index) {
+		return res, ErrNotSigner
+	}
+
+	if signers >= 1<<uint(len(p.publicKeys)) || signers.Len() < p.threshold {
+		return res, ErrNotEnoughSigners
+	}
+
+	for s, secret := range p.secrets {
+		if first := s & signers; first&-first == NewSubset(p.index) {
+			ed25519.ScAdd((*[32]byte)(&res), (*[32]byte)(&res), (*[32]byte)(&secret))
+		}
+	}
+
+	return res, nil
+}
+
+// SharedViewKey combines a secret view key from each participant into the
+// secret view key of the wallet, which every participant knows
+func SharedViewKey(viewKeys []keys.SecretKey) (keys.SecretKey, error) {
+	var res keys.SecretKey
+
+	for _, key := range viewKeys {
+		if !ed25519.ScCheck((*[32]byte)(&key)) {
+			return keys.SecretKey{}, keys.ErrInvalidSecretKey
+		}
+
+		ed25519.ScAdd((*[32]byte)(&res), (*[32]byte)(&res), (*[32]byte)(&key))
+	}
+
+	return res, nil
+}
+
+// addKeys returns the sum of the points in publicKeys
+func addKeys(publicKeys []keys.PublicKey) (keys.PublicKey, error) {
+	var sum, point ed25519.ExtendedGroupElement
+	var cached ed25519.CachedGroupElement
+	var tmp ed25519.CompletedGroupElement
+	var res keys.PublicKey
+
+	sum.Zero()
+
+	for _, public := range publicKeys {
+		if !point.FromBytes((*[32]byte)(&public)) {
+			return keys.PublicKey{}, keys.ErrInvalidPublicKey
+		}
+
+		point.ToCached(&cached)
+
+		ed25519.GeAdd(&tmp, &sum, &cached)
+
+		tmp.ToExtended(&sum)
+	}
+
+	sum.ToBytes((*[32]byte)(&res))
+
+	return res, nil
+}
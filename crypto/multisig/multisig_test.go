@@ -0,0 +1,226 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package multisig
+
+import (
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/crypto/signatures"
+)
+
+// newWallet generates n spend keys with their proofs and starts the setup
+// of an m of n wallet for each, in the order of the participant indexes
+func newWallet(t *testing.T, m, n int) []*Participant {
+	t.Helper()
+
+	publicKeys := make([]keys.PublicKey, n)
+	secretKeys := make([]keys.SecretKey, n)
+	proofs := make([]signatures.Signature, n)
+
+	for i := range publicKeys {
+		var err error
+
+		publicKeys[i], secretKeys[i], err = keys.GenerateKeys()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if proofs[i], err = ProveKey(secretKeys[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res := make([]*Participant, n)
+
+	for _, secret := range secretKeys {
+		p, err := NewParticipant(m, secret, publicKeys, proofs)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res[p.Index()] = p
+	}
+
+	return res
+}
+
+// publish merges the round keys of every participant
+func publish(t *testing.T, participants []*Participant) map[Subset]keys.PublicKey {
+	t.Helper()
+
+	res := make(map[Subset]keys.PublicKey)
+
+	for _, p := range participants {
+		roundKeys, err := p.RoundKeys()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for s, public := range roundKeys {
+			res[s] = public
+		}
+	}
+
+	return res
+}
+
+// setup runs every round of the setup and returns the public spend key
+func setup(t *testing.T, participants []*Participant) keys.PublicKey {
+	t.Helper()
+
+	for !participants[0].Complete() {
+		published := publish(t, participants)
+
+		for _, p := range participants {
+			if err := p.NextRound(published); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	published := publish(t, participants)
+
+	spend, err := participants[0].SpendPublicKey(published)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range participants[1:] {
+		if other, err := p.SpendPublicKey(published); err != nil || other != spend {
+			t.Fatalf("participant %d: spend key %v, %v, want %v", p.Index(), other, err, spend)
+		}
+	}
+
+	return spend
+}
+
+func TestSigningKeys(t *testing.T) {
+	for _, size := range []struct{ m, n int }{{1, 1}, {2, 2}, {3, 3}, {1, 2}, {2, 3}, {3, 5}, {2, 5}} {
+		participants := newWallet(t, size.m, size.n)
+		spend := setup(t, participants)
+
+		// the signing keys of the first m and the last m participants
+		// both add up to the secret spend key
+		for _, signers := range []Subset{Subset(1<<uint(size.m) - 1), Subset(1<<uint(size.m)-1) << uint(size.n-size.m)} {
+			var sum keys.SecretKey
+
+			for i, p := range participants {
+				if !signers.Contains(i) {
+					continue
+				}
+
+				key, err := p.SigningKey(signers)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				ed25519.ScAdd((*[32]byte)(&sum), (*[32]byte)(&sum), (*[32]byte)(&key))
+			}
+
+			if public, _ := keys.SecretKeyToPublicKey(sum); public != spend {
+				t.Errorf("%d of %d, signers %b: signing keys add up to %v, want %v", size.m, size.n, signers, public, spend)
+			}
+		}
+	}
+}
+
+// TestRogueKey checks a participant can't publish a key which cancels out
+// the key of the other participant, to control an n of n wallet alone
+func TestRogueKey(t *testing.T) {
+	var point, evilPoint ed25519.ExtendedGroupElement
+	var cached ed25519.CachedGroupElement
+	var tmp ed25519.CompletedGroupElement
+	var rogue keys.PublicKey
+
+	honestPublic, honestSecret, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evilPublic, evilSecret, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rogue = evil - honest, so their sum is the key of evilSecret
+	evilPoint.FromBytes((*[32]byte)(&evilPublic))
+	point.FromBytes((*[32]byte)(&honestPublic))
+	point.ToCached(&cached)
+	ed25519.GeSub(&tmp, &evilPoint, &cached)
+	tmp.ToExtended(&point)
+	point.ToBytes((*[32]byte)(&rogue))
+
+	if sum, _ := addKeys([]keys.PublicKey{honestPublic, rogue}); sum != evilPublic {
+		t.Fatal("rogue key does not cancel out the honest key")
+	}
+
+	honestProof, err := ProveKey(honestSecret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evilProof, err := ProveKey(evilSecret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := []keys.PublicKey{honestPublic, rogue}
+
+	// the attacker doesn't know the secret key of rogue, so can only
+	// offer the proof of another key
+	for _, forged := range []signatures.Signature{evilProof, honestProof, {}} {
+		proofs := []signatures.Signature{honestProof, forged}
+
+		if _, err := NewParticipant(2, honestSecret, publicKeys, proofs); err != ErrInvalidProof {
+			t.Errorf("forged proof: err = %v, want %v", err, ErrInvalidProof)
+		}
+	}
+
+	if _, err := NewParticipant(2, honestSecret, publicKeys, []signatures.Signature{honestProof}); err != ErrInvalidProof {
+		t.Errorf("missing proof: err = %v, want %v", err, ErrInvalidProof)
+	}
+}
+
+// TestKeyMismatch checks a participant rejects a published key which
+// differs from the key it calculated for the subset
+func TestKeyMismatch(t *testing.T) {
+	participants := newWallet(t, 2, 3)
+	published := publish(t, participants)
+
+	for _, p := range participants {
+		if err := p.NextRound(published); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	published = publish(t, participants)
+
+	evilPublic, _, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	published[NewSubset(0, 1)] = evilPublic
+
+	if _, err := participants[0].SpendPublicKey(published); err != ErrKeyMismatch {
+		t.Errorf("err = %v, want %v", err, ErrKeyMismatch)
+	}
+}
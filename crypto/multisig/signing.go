@@ -0,0 +1,148 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package multisig
+
+import (
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/crypto/signatures"
+)
+
+// The secret key of an output received by the wallet is
+// x = Hs(derivation || outputIndex) + a, where a is the secret spend key.
+// Nobody knows a, so its key image and ring signatures are put together
+// from the parts contributed by each signer, see Participant.SigningKey.
+//
+// A ring signature is made in two rounds. First every signer calls
+// GenerateSigningNonce and publishes the commitment, keeping the nonce
+// secret. The commitments are added up with CombineCommitments and passed
+// to signatures.PrepareRingSignature. Then every signer publishes the
+// partial signature from GeneratePartialSignature, and
+// CombineRingSignature completes the signature from them. Each partial
+// signature is k - c * s for a nonce k only its signer knows, so it
+// reveals nothing about the signing key s. A nonce must never be used for
+// more than one signature.
+
+// GeneratePartialKeyImage calculates the part of the key image of the
+// output key public contributed by a signer, signingKey * Hp(public)
+func GeneratePartialKeyImage(public keys.PublicKey, signingKey keys.SecretKey) (keys.KeyImage, error) {
+	return keys.GenerateKeyImage(public, signingKey)
+}
+
+// CombineKeyImages calculates the key image of the output key public from
+// the partial key images of every signer,
+// Hs(derivation || outputIndex) * Hp(public) + the sum of partials
+func CombineKeyImages(derivation keys.KeyDerivation, outputIndex uint64, public keys.PublicKey, partials []keys.KeyImage) (keys.KeyImage, error) {
+	scalar := keys.DerivationToScalar(derivation, outputIndex)
+
+	image, err := keys.GenerateKeyImage(public, keys.SecretKey(scalar))
+
+	if err != nil {
+		return keys.KeyImage{}, err
+	}
+
+	points := []keys.PublicKey{keys.PublicKey(image)}
+
+	for _, partial := range partials {
+		points = append(points, keys.PublicKey(partial))
+	}
+
+	sum, err := addKeys(points)
+
+	if err != nil {
+		return keys.KeyImage{}, signatures.ErrInvalidKeyImage
+	}
+
+	return keys.KeyImage(sum), nil
+}
+
+// GenerateSigningNonce returns a new random nonce k for a signature
+// spending the output key public, and its commitment, which is published
+// to the other signers
+func GenerateSigningNonce(public keys.PublicKey) (keys.EllipticCurveScalar, signatures.RingCommitment, error) {
+	k, err := keys.RandomScalar()
+
+	if err != nil {
+		return keys.EllipticCurveScalar{}, signatures.RingCommitment{}, err
+	}
+
+	return k, signatures.GenerateRingCommitment(public, k), nil
+}
+
+// CombineCommitments adds up the commitments published by every signer
+// into the commitment of the real output of the ring signature
+func CombineCommitments(commitments []signatures.RingCommitment) (signatures.RingCommitment, error) {
+	var a, b []keys.PublicKey
+	var res signatures.RingCommitment
+	var err error
+
+	for _, commitment := range commitments {
+		a = append(a, commitment.A)
+		b = append(b, commitment.B)
+	}
+
+	if res.A, err = addKeys(a); err != nil {
+		return signatures.RingCommitment{}, err
+	}
+
+	if res.B, err = addKeys(b); err != nil {
+		return signatures.RingCommitment{}, err
+	}
+
+	return res, nil
+}
+
+// GeneratePartialSignature calculates the part of the response of the
+// real output in a ring signature contributed by a signer,
+// k - c * signingKey, where k is the nonce of the signer and sig is the
+// signature of the real output from signatures.PrepareRingSignature
+func GeneratePartialSignature(sig signatures.Signature, k keys.EllipticCurveScalar, signingKey keys.SecretKey) (keys.EllipticCurveScalar, error) {
+	var c [32]byte
+	var res keys.EllipticCurveScalar
+
+	if !ed25519.ScCheck((*[32]byte)(&signingKey)) || !ed25519.ScCheck((*[32]byte)(&k)) {
+		return keys.EllipticCurveScalar{}, keys.ErrInvalidSecretKey
+	}
+
+	copy(c[:], sig[:32])
+
+	ed25519.ScMulSub((*[32]byte)(&res), &c, (*[32]byte)(&signingKey), (*[32]byte)(&k))
+
+	return res, nil
+}
+
+// CombineRingSignature completes the ring signature sigs, prepared by
+// signatures.PrepareRingSignature with the combined commitments of the
+// signers, from the partial signatures of every signer. The response of
+// the real output is the sum of partials - c * Hs(derivation || outputIndex).
+func CombineRingSignature(sigs []signatures.Signature, secretIndex int, derivation keys.KeyDerivation, outputIndex uint64, partials []keys.EllipticCurveScalar) error {
+	var c, r [32]byte
+
+	if secretIndex < 0 || secretIndex >= len(sigs) {
+		return signatures.ErrInvalidSecretIndex
+	}
+
+	for _, partial := range partials {
+		if !ed25519.ScCheck((*[32]byte)(&partial)) {
+			return keys.ErrInvalidSecretKey
+		}
+
+		ed25519.ScAdd(&r, &r, (*[32]byte)(&partial))
+	}
+
+	scalar := keys.DerivationToScalar(derivation, outputIndex)
+
+	copy(c[:], sigs[secretIndex][:32])
+
+	ed25519.ScMulSub(&r, &c, (*[32]byte)(&scalar), &r)
+
+	copy(sigs[secretIndex][32:], r[:])
+
+	return nil
+}
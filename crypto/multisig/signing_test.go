@@ -0,0 +1,282 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package multisig
+
+import (
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/crypto/signatures"
+)
+
+// output is an output received by a multisig wallet
+type output struct {
+	derivation keys.KeyDerivation
+	index      uint64
+	public     keys.PublicKey
+
+	// secret is the secret key of the output, which no participant knows
+	secret keys.SecretKey
+}
+
+// newSigners sets up a 2 of 3 wallet and returns the signing keys of
+// participants 0 and 2, and an output received by the wallet
+func newSigners(t *testing.T) ([]keys.SecretKey, output) {
+	t.Helper()
+
+	var res []keys.SecretKey
+	var spendSecret keys.SecretKey
+	var out output
+
+	participants := newWallet(t, 2, 3)
+	spend := setup(t, participants)
+	signers := NewSubset(0, 2)
+
+	for _, i := range []int{0, 2} {
+		key, err := participants[i].SigningKey(signers)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res = append(res, key)
+
+		ed25519.ScAdd((*[32]byte)(&spendSecret), (*[32]byte)(&spendSecret), (*[32]byte)(&key))
+	}
+
+	viewPublic, _, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, txSecret, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.derivation, err = keys.GenerateKeyDerivation(viewPublic, txSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	out.index = 3
+
+	if out.public, err = keys.DerivePublicKey(out.derivation, out.index, spend); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.secret, err = keys.DeriveSecretKey(out.derivation, out.index, spendSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	return res, out
+}
+
+func TestCombineKeyImages(t *testing.T) {
+	signingKeys, out := newSigners(t)
+
+	want, err := keys.GenerateKeyImage(out.public, out.secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var partials []keys.KeyImage
+
+	for _, key := range signingKeys {
+		partial, err := GeneratePartialKeyImage(out.public, key)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		partials = append(partials, partial)
+	}
+
+	if image, err := CombineKeyImages(out.derivation, out.index, out.public, partials); err != nil || image != want {
+		t.Errorf("CombineKeyImages = %v, %v, want %v", image, err, want)
+	}
+
+	if image, _ := CombineKeyImages(out.derivation, out.index, out.public, partials[1:]); image == want {
+		t.Error("key image combined without every partial")
+	}
+
+	if image, _ := CombineKeyImages(out.derivation, out.index+1, out.public, partials); image == want {
+		t.Error("key image combined with another output index")
+	}
+
+	// y = 2 is not the y coordinate of a point on the curve
+	if _, err := CombineKeyImages(out.derivation, out.index, out.public, []keys.KeyImage{partials[0], {2}}); err != signatures.ErrInvalidKeyImage {
+		t.Errorf("err = %v, want %v", err, signatures.ErrInvalidKeyImage)
+	}
+}
+
+// signRing makes a ring signature of prefixHash over ring, which has the
+// output at secretIndex, with signing keys. nonces[i] is used by signer i
+// for the partial signature, commitments[i] is the commitment it
+// published. The partial signatures are returned as well.
+func signRing(t *testing.T, prefixHash keys.Hash, image keys.KeyImage, ring []keys.PublicKey, secretIndex int, out output, signingKeys []keys.SecretKey, nonces []keys.EllipticCurveScalar, commitments []signatures.RingCommitment) ([]signatures.Signature, []keys.EllipticCurveScalar) {
+	t.Helper()
+
+	combined, err := CombineCommitments(commitments)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigs, err := signatures.PrepareRingSignature(prefixHash, image, ring, secretIndex, combined)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var partials []keys.EllipticCurveScalar
+
+	for i, key := range signingKeys {
+		partial, err := GeneratePartialSignature(sigs[secretIndex], nonces[i], key)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		partials = append(partials, partial)
+	}
+
+	if err := CombineRingSignature(sigs, secretIndex, out.derivation, out.index, partials); err != nil {
+		t.Fatal(err)
+	}
+
+	return sigs, partials
+}
+
+// newNonces returns a nonce and its commitment for each signer
+func newNonces(t *testing.T, public keys.PublicKey, count int) ([]keys.EllipticCurveScalar, []signatures.RingCommitment) {
+	t.Helper()
+
+	var nonces []keys.EllipticCurveScalar
+	var commitments []signatures.RingCommitment
+
+	for i := 0; i < count; i++ {
+		k, commitment, err := GenerateSigningNonce(public)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		nonces = append(nonces, k)
+		commitments = append(commitments, commitment)
+	}
+
+	return nonces, commitments
+}
+
+func TestCombineRingSignature(t *testing.T) {
+	signingKeys, out := newSigners(t)
+	prefixHash := keys.Hash{1, 2, 3}
+	secretIndex := 2
+
+	image, err := keys.GenerateKeyImage(out.public, out.secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ring := make([]keys.PublicKey, 4)
+
+	for i := range ring {
+		if ring[i], _, err = keys.GenerateKeys(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ring[secretIndex] = out.public
+
+	nonces, commitments := newNonces(t, out.public, len(signingKeys))
+	sigs, partials := signRing(t, prefixHash, image, ring, secretIndex, out, signingKeys, nonces, commitments)
+
+	if !signatures.CheckRingSignature(prefixHash, image, ring, sigs) {
+		t.Fatal("combined ring signature is invalid")
+	}
+
+	// a partial signature is not c times the signing key, and changes
+	// with the nonce, so it doesn't reveal the signing key
+	var c, zero [32]byte
+
+	copy(c[:], sigs[secretIndex][:32])
+
+	for i, key := range signingKeys {
+		var product keys.EllipticCurveScalar
+
+		ed25519.ScMulAdd((*[32]byte)(&product), &c, (*[32]byte)(&key), &zero)
+
+		if partials[i] == product {
+			t.Errorf("partial signature %d is c * signing key", i)
+		}
+	}
+
+	otherNonces, otherCommitments := newNonces(t, out.public, len(signingKeys))
+	sigs, otherPartials := signRing(t, prefixHash, image, ring, secretIndex, out, signingKeys, otherNonces, otherCommitments)
+
+	if !signatures.CheckRingSignature(prefixHash, image, ring, sigs) {
+		t.Fatal("second combined ring signature is invalid")
+	}
+
+	for i := range partials {
+		if partials[i] == otherPartials[i] {
+			t.Errorf("signer %d gave the same partial signature with a new nonce", i)
+		}
+	}
+
+	// a signer whose nonce doesn't match its commitment
+	nonces[1] = otherNonces[1]
+
+	if sigs, _ := signRing(t, prefixHash, image, ring, secretIndex, out, signingKeys, nonces, commitments); signatures.CheckRingSignature(prefixHash, image, ring, sigs) {
+		t.Error("ring signature valid with a nonce which doesn't match its commitment")
+	}
+
+	// a signer which didn't take part
+	if sigs, _ := signRing(t, prefixHash, image, ring, secretIndex, out, signingKeys[:1], otherNonces[:1], otherCommitments[:1]); signatures.CheckRingSignature(prefixHash, image, ring, sigs) {
+		t.Error("ring signature valid without every signer")
+	}
+}
+
+func TestSigningErrors(t *testing.T) {
+	var unreduced keys.EllipticCurveScalar
+
+	for i := range unreduced {
+		unreduced[i] = 0xff
+	}
+
+	sigs := make([]signatures.Signature, 3)
+
+	for _, index := range []int{-1, 3} {
+		if err := CombineRingSignature(sigs, index, keys.KeyDerivation{}, 0, nil); err != signatures.ErrInvalidSecretIndex {
+			t.Errorf("index %d: err = %v, want %v", index, err, signatures.ErrInvalidSecretIndex)
+		}
+	}
+
+	if err := CombineRingSignature(sigs, 0, keys.KeyDerivation{}, 0, []keys.EllipticCurveScalar{unreduced}); err != keys.ErrInvalidSecretKey {
+		t.Errorf("err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+
+	if _, err := GeneratePartialSignature(sigs[0], unreduced, keys.SecretKey{1}); err != keys.ErrInvalidSecretKey {
+		t.Errorf("err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+
+	if _, err := GeneratePartialSignature(sigs[0], keys.EllipticCurveScalar{1}, keys.SecretKey(unreduced)); err != keys.ErrInvalidSecretKey {
+		t.Errorf("err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+
+	// y = 2 is not the y coordinate of a point on the curve
+	if _, err := CombineCommitments([]signatures.RingCommitment{{A: keys.PublicKey{2}}}); err != keys.ErrInvalidPublicKey {
+		t.Errorf("err = %v, want %v", err, keys.ErrInvalidPublicKey)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package signatures
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// RingSignatureEntry is one ring signature to be checked by
+// CheckRingSignatures, usually one input of a transaction
+type RingSignatureEntry struct {
+	PrefixHash keys.Hash
+	KeyImage   keys.KeyImage
+	PublicKeys []keys.PublicKey
+	Signatures []Signature
+}
+
+// CheckRingSignatures checks every entry using at most workers goroutines,
+// or one per CPU if workers is 0 or less, and returns the indexes of the
+// entries which failed in ascending order. It returns nil if every
+// signature is valid.
+//
+// Each ring member's commitments are hashed individually, so the work
+// can't be folded into a single multi scalar multiplication. Every member
+// is already computed with two double scalar multiplications, sharing the
+// precomputed multiples of the key image across the ring.
+func CheckRingSignatures(entries []RingSignatureEntry, workers int) []int {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	if workers > len(entries) {
+		workers = len(entries)
+	}
+
+	valid := make([]bool, len(entries))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := range indexes {
+				entry := &entries[j]
+
+				valid[j] = CheckRingSignature(entry.PrefixHash, entry.KeyImage, entry.PublicKeys, entry.Signatures)
+			}
+		}()
+	}
+
+	for i := range entries {
+		indexes <- i
+	}
+
+	close(indexes)
+
+	wg.Wait()
+
+	var failed []int
+
+	for i, ok := range valid {
+		if !ok {
+			failed = append(failed, i)
+		}
+	}
+
+	return failed
+}
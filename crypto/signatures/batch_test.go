@@ -0,0 +1,79 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package signatures
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// batchEntries returns the check_ring_signature vectors as entries, and
+// the indexes of the invalid ones
+func batchEntries(t *testing.T) ([]RingSignatureEntry, []int) {
+	t.Helper()
+
+	var entries []RingSignatureEntry
+	var failed []int
+
+	for i, test := range ringTests(t) {
+		entries = append(entries, RingSignatureEntry{
+			PrefixHash: test.prefixHash,
+			KeyImage:   test.image,
+			PublicKeys: test.publicKeys,
+			Signatures: test.sigs,
+		})
+
+		if !test.valid {
+			failed = append(failed, i)
+		}
+	}
+
+	return entries, failed
+}
+
+func TestCheckRingSignatures(t *testing.T) {
+	entries, failed := batchEntries(t)
+
+	for _, workers := range []int{-1, 0, 1, 3, runtime.NumCPU(), len(entries), len(entries) + 10} {
+		if res := CheckRingSignatures(entries, workers); !reflect.DeepEqual(res, failed) {
+			t.Errorf("%d workers: failed %v, want %v", workers, res, failed)
+		}
+	}
+
+	// the valid entries alone, and one invalid entry among them
+	var valid []RingSignatureEntry
+
+	for i, entry := range entries {
+		if len(failed) == 0 || failed[0] != i {
+			valid = append(valid, entry)
+			continue
+		}
+
+		failed = failed[1:]
+	}
+
+	if res := CheckRingSignatures(valid, 4); res != nil {
+		t.Errorf("valid entries: failed %v, want nil", res)
+	}
+
+	valid[7].Signatures = valid[7].Signatures[1:]
+
+	if res := CheckRingSignatures(valid, 4); !reflect.DeepEqual(res, []int{7}) {
+		t.Errorf("one invalid entry: failed %v, want [7]", res)
+	}
+}
+
+func TestCheckRingSignaturesEmpty(t *testing.T) {
+	for _, workers := range []int{-1, 0, 1, 8} {
+		if res := CheckRingSignatures(nil, workers); res != nil {
+			t.Errorf("%d workers: failed %v, want nil", workers, res)
+		}
+	}
+}
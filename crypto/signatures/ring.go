@@ -0,0 +1,219 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package signatures
+
+import (
+	"errors"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// ErrInvalidKeyImage is returned when a key image is not a valid point
+var ErrInvalidKeyImage = errors.New("key image is not a valid point")
+
+// ErrInvalidSecretIndex is returned when the index of the real output
+// is outside of the ring
+var ErrInvalidSecretIndex = errors.New("secret index is outside of the ring")
+
+// ringBuffer is the rs_comm struct which is hashed to get the sum of the
+// challenges, prefixHash || (a_0 || b_0) || ... || (a_n || b_n)
+func ringBuffer(prefixHash keys.Hash, count int) []byte {
+	buf := make([]byte, 32+64*count)
+
+	copy(buf, prefixHash[:])
+
+	return buf
+}
+
+// ringMember calculates the commitments of a ring member which is not the
+// signer, a = r * B + c * P and b = r * Hp(P) + c * I, into buf
+func ringMember(buf []byte, public *ed25519.ExtendedGroupElement, publicKey keys.PublicKey, imagePre *ed25519.DsmPrecomp, sig *Signature) {
+	var tmp2 ed25519.ProjectiveGroupElement
+
+	ed25519.GeDoubleScalarMultBaseVartime(&tmp2, sig.c(), public, sig.r())
+
+	tmp2.ToBytes((*[32]byte)(buf[:32]))
+
+	hashed := keys.HashToEC(publicKey)
+
+	ed25519.GeDoubleScalarMultPrecompVartime(&tmp2, sig.r(), &hashed, sig.c(), imagePre)
+
+	tmp2.ToBytes((*[32]byte)(buf[32:64]))
+}
+
+// RingCommitment is the pair of commitments of the real output of a ring
+// signature, A is k times the base point and B is k * Hp(P), where k is a
+// random scalar and P the public key of the output
+type RingCommitment struct {
+	A, B keys.PublicKey
+}
+
+// GenerateRingCommitment calculates the commitments of the real output
+// publicKey with the random scalar k
+func GenerateRingCommitment(publicKey keys.PublicKey, k keys.EllipticCurveScalar) RingCommitment {
+	var tmp3 ed25519.ExtendedGroupElement
+	var res RingCommitment
+
+	ed25519.GeScalarMultBase(&tmp3, (*[32]byte)(&k))
+
+	tmp3.ToBytes((*[32]byte)(&res.A))
+
+	hashed := keys.HashToEC(publicKey)
+
+	ed25519.GeScalarMult(&tmp3, (*[32]byte)(&k), &hashed)
+
+	tmp3.ToBytes((*[32]byte)(&res.B))
+
+	return res
+}
+
+// GenerateRingSignature signs prefixHash with the one time ring
+// signature over publicKeys, where secret is the secret key of
+// publicKeys[secretIndex] and image its key image. The other public
+// keys are the decoys, the signature does not reveal which key signed.
+func GenerateRingSignature(prefixHash keys.Hash, image keys.KeyImage, publicKeys []keys.PublicKey, secret keys.SecretKey, secretIndex int) ([]Signature, error) {
+	if !ed25519.ScCheck((*[32]byte)(&secret)) {
+		return nil, keys.ErrInvalidSecretKey
+	}
+
+	if secretIndex < 0 || secretIndex >= len(publicKeys) {
+		return nil, ErrInvalidSecretIndex
+	}
+
+	k, err := keys.RandomScalar()
+
+	if err != nil {
+		return nil, err
+	}
+
+	commitment := GenerateRingCommitment(publicKeys[secretIndex], k)
+
+	sigs, err := PrepareRingSignature(prefixHash, image, publicKeys, secretIndex, commitment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ed25519.ScMulSub(sigs[secretIndex].r(), sigs[secretIndex].c(), (*[32]byte)(&secret), (*[32]byte)(&k))
+
+	return sigs, nil
+}
+
+// PrepareRingSignature does all of the work of GenerateRingSignature
+// which doesn't need the secret key, given the commitment of the real
+// output. The r of sigs[secretIndex] is left empty, it is
+// k - c * secret where c is the challenge of sigs[secretIndex]. This
+// allows the signature to be completed by the holders of the parts of a
+// multisig secret key, each of which contributes a part of k.
+func PrepareRingSignature(prefixHash keys.Hash, image keys.KeyImage, publicKeys []keys.PublicKey, secretIndex int, commitment RingCommitment) ([]Signature, error) {
+	var imageUnp ed25519.ExtendedGroupElement
+	var imagePre ed25519.DsmPrecomp
+	var sum [32]byte
+
+	if secretIndex < 0 || secretIndex >= len(publicKeys) {
+		return nil, ErrInvalidSecretIndex
+	}
+
+	if !imageUnp.FromBytes((*[32]byte)(&image)) {
+		return nil, ErrInvalidKeyImage
+	}
+
+	ed25519.GeDsmPrecomp(&imagePre, &imageUnp)
+
+	sigs := make([]Signature, len(publicKeys))
+	buf := ringBuffer(prefixHash, len(publicKeys))
+
+	for i, publicKey := range publicKeys {
+		var tmp3 ed25519.ExtendedGroupElement
+
+		ab := buf[32+64*i : 32+64*(i+1)]
+
+		if i == secretIndex {
+			copy(ab[:32], commitment.A[:])
+			copy(ab[32:], commitment.B[:])
+
+			continue
+		}
+
+		c, err := keys.RandomScalar()
+
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := keys.RandomScalar()
+
+		if err != nil {
+			return nil, err
+		}
+
+		copy(sigs[i].c()[:], c[:])
+		copy(sigs[i].r()[:], r[:])
+
+		if !tmp3.FromBytes((*[32]byte)(&publicKey)) {
+			return nil, keys.ErrInvalidPublicKey
+		}
+
+		ringMember(ab, &tmp3, publicKey, &imagePre, &sigs[i])
+
+		ed25519.ScAdd(&sum, &sum, sigs[i].c())
+	}
+
+	h := keys.HashToScalar(buf)
+
+	ed25519.ScSub(sigs[secretIndex].c(), (*[32]byte)(&h), &sum)
+
+	return sigs, nil
+}
+
+// CheckRingSignature returns true if sigs is a valid ring signature of
+// prefixHash over publicKeys with the key image image. It does not check
+// that image is in the prime order subgroup, see keys.CheckKeyImage.
+func CheckRingSignature(prefixHash keys.Hash, image keys.KeyImage, publicKeys []keys.PublicKey, sigs []Signature) bool {
+	var imageUnp ed25519.ExtendedGroupElement
+	var imagePre ed25519.DsmPrecomp
+	var sum [32]byte
+
+	if len(sigs) != len(publicKeys) {
+		return false
+	}
+
+	if !imageUnp.FromBytes((*[32]byte)(&image)) {
+		return false
+	}
+
+	ed25519.GeDsmPrecomp(&imagePre, &imageUnp)
+
+	buf := ringBuffer(prefixHash, len(publicKeys))
+
+	for i, publicKey := range publicKeys {
+		var tmp3 ed25519.ExtendedGroupElement
+
+		if !ed25519.ScCheck(sigs[i].c()) || !ed25519.ScCheck(sigs[i].r()) {
+			return false
+		}
+
+		if !tmp3.FromBytes((*[32]byte)(&publicKey)) {
+			return false
+		}
+
+		ringMember(buf[32+64*i:32+64*(i+1)], &tmp3, publicKey, &imagePre, &sigs[i])
+
+		ed25519.ScAdd(&sum, &sum, sigs[i].c())
+	}
+
+	h := keys.HashToScalar(buf)
+
+	ed25519.ScSub((*[32]byte)(&h), (*[32]byte)(&h), &sum)
+
+	return !ed25519.ScIsNonZero((*[32]byte)(&h))
+}
@@ -0,0 +1,304 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package signatures
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// ringTest is a line of check_ring_signature
+type ringTest struct {
+	prefixHash keys.Hash
+	image      keys.KeyImage
+	publicKeys []keys.PublicKey
+	sigs       []Signature
+	valid      bool
+}
+
+// parseRingTest parses the arguments of a check_ring_signature line, the
+// prefix hash, the key image, the ring size and public keys, the
+// signatures as one hex string and the result
+func parseRingTest(t *testing.T, args []string) ringTest {
+	t.Helper()
+
+	var res ringTest
+
+	fromHex(t, res.prefixHash[:], args[0])
+	fromHex(t, res.image[:], args[1])
+
+	count, err := strconv.Atoi(args[2])
+
+	if err != nil || len(args) != count+5 {
+		t.Fatalf("bad check_ring_signature line %v", args)
+	}
+
+	res.publicKeys = make([]keys.PublicKey, count)
+
+	for i := range res.publicKeys {
+		fromHex(t, res.publicKeys[i][:], args[3+i])
+	}
+
+	res.sigs = make([]Signature, count)
+
+	for i := range res.sigs {
+		fromHex(t, res.sigs[i][:], args[3+count][128*i:128*(i+1)])
+	}
+
+	res.valid = args[4+count] == "true"
+
+	return res
+}
+
+// ringTests returns the check_ring_signature lines of testdata/tests.txt,
+// a selection of those in tests/crypto/tests.txt of the reference code,
+// as carried by the paxosglobal/moneroutil tests
+func ringTests(t *testing.T) []ringTest {
+	t.Helper()
+
+	f, err := os.Open("testdata/tests.txt")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	var res []ringTest
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) != 0 && fields[0] == "check_ring_signature" {
+			res = append(res, parseRingTest(t, fields[1:]))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res) == 0 {
+		t.Fatal("no check_ring_signature vectors")
+	}
+
+	return res
+}
+
+// mainnetInput is input 0 of transaction
+// ca9ea576d67af4926e31ebeb159aaee58950aea18e5e0ad0bae23b2d85ede8c1 in
+// Monero block 40646, which spends a ring of one output
+var mainnetInput = []string{
+	"aeecb4170b276d2ac69a7abca86f82621f56d943c8d4a8900cd56192da8d442d",
+	"c9679ba9ca8a6fa87a1352985e46ea3723489d3699ab1af075532f711739b9c5",
+	"1",
+	"6646f168c842275b31ca863f6eac8eed9e5dfc5714d5864efb62f6c340298a30",
+	"11b4d1bd92e85f38152848cbf100c6f8b15c9de5278e4506bb9131230807d60e658188593715e7980a9d9e188d2114f2a3b71541cfe66fb94413237edf36dc0a",
+	"true",
+}
+
+func TestCheckRingSignature(t *testing.T) {
+	for i, test := range append(ringTests(t), parseRingTest(t, mainnetInput)) {
+		if CheckRingSignature(test.prefixHash, test.image, test.publicKeys, test.sigs) != test.valid {
+			t.Errorf("vector %d, ring of %d: want %v", i, len(test.publicKeys), test.valid)
+		}
+	}
+}
+
+// newRing generates a ring of size keys, and the secret key and key image
+// of the one at secretIndex
+func newRing(t *testing.T, size, secretIndex int) ([]keys.PublicKey, keys.SecretKey, keys.KeyImage) {
+	t.Helper()
+
+	ring := make([]keys.PublicKey, size)
+
+	var secret keys.SecretKey
+
+	for i := range ring {
+		public, s, err := keys.GenerateKeys()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ring[i] = public
+
+		if i == secretIndex {
+			secret = s
+		}
+	}
+
+	image, err := keys.GenerateKeyImage(ring[secretIndex], secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ring, secret, image
+}
+
+// otherImage returns the key image of a new key
+func otherImage(t *testing.T) keys.KeyImage {
+	t.Helper()
+
+	public, secret, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image, err := keys.GenerateKeyImage(public, secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return image
+}
+
+func TestRingSignature(t *testing.T) {
+	prefixHash := keys.Hash{1, 2, 3}
+
+	for _, size := range []int{1, 2, 3, 5, 11} {
+		for _, secretIndex := range []int{0, size / 2, size - 1} {
+			ring, secret, image := newRing(t, size, secretIndex)
+
+			sigs, err := GenerateRingSignature(prefixHash, image, ring, secret, secretIndex)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !CheckRingSignature(prefixHash, image, ring, sigs) {
+				t.Errorf("ring of %d, index %d: signature is invalid", size, secretIndex)
+				continue
+			}
+
+			if CheckRingSignature(keys.Hash{3, 2, 1}, image, ring, sigs) {
+				t.Errorf("ring of %d, index %d: valid for another prefix", size, secretIndex)
+			}
+
+			if CheckRingSignature(prefixHash, otherImage(t), ring, sigs) {
+				t.Errorf("ring of %d, index %d: valid with another key image", size, secretIndex)
+			}
+
+			for i := range ring {
+				other := append([]keys.PublicKey(nil), ring...)
+				other[i], _, _ = keys.GenerateKeys()
+
+				if CheckRingSignature(prefixHash, image, other, sigs) {
+					t.Errorf("ring of %d, index %d: valid with member %d replaced", size, secretIndex, i)
+				}
+
+				for _, b := range []int{0, 32} {
+					tampered := append([]Signature(nil), sigs...)
+					tampered[i][b] ^= 1
+
+					if CheckRingSignature(prefixHash, image, ring, tampered) {
+						t.Errorf("ring of %d, index %d: valid with byte %d of signature %d changed", size, secretIndex, b, i)
+					}
+				}
+			}
+
+			if CheckRingSignature(prefixHash, image, ring, sigs[1:]) {
+				t.Errorf("ring of %d, index %d: valid with a signature missing", size, secretIndex)
+			}
+
+			if CheckRingSignature(prefixHash, image, ring[1:], sigs) {
+				t.Errorf("ring of %d, index %d: valid with a ring member missing", size, secretIndex)
+			}
+		}
+	}
+}
+
+// order8 is the encoding of a point of order 8
+const order8 = "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05"
+
+// TestTorsionKeyImage checks a key image with a torsion component, which
+// could spend an output a second time, is caught. CheckRingSignature
+// accepts such signatures whenever 8 divides the signer's challenge, as
+// the reference does, and leaves rejecting the image to keys.CheckKeyImage.
+func TestTorsionKeyImage(t *testing.T) {
+	var p, q ed25519.ExtendedGroupElement
+	var cached ed25519.CachedGroupElement
+	var sum ed25519.CompletedGroupElement
+	var torsion, enc [32]byte
+
+	prefixHash := keys.Hash{4, 5, 6}
+	ring, secret, image := newRing(t, 3, 1)
+
+	fromHex(t, enc[:], order8)
+
+	p.FromBytes((*[32]byte)(&image))
+	q.FromBytes(&enc)
+	q.ToCached(&cached)
+	ed25519.GeAdd(&sum, &p, &cached)
+	sum.ToExtended(&p)
+	p.ToBytes(&torsion)
+
+	forged := false
+
+	for i := 0; i < 256 && !forged; i++ {
+		sigs, err := GenerateRingSignature(prefixHash, torsion, ring, secret, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		forged = CheckRingSignature(prefixHash, torsion, ring, sigs)
+	}
+
+	if !forged {
+		t.Fatal("no signature with the torsion key image verified")
+	}
+
+	if keys.CheckKeyImage(torsion) {
+		t.Error("key image with a torsion component accepted")
+	}
+}
+
+func TestGenerateRingSignatureErrors(t *testing.T) {
+	var unreduced keys.SecretKey
+
+	for i := range unreduced {
+		unreduced[i] = 0xff
+	}
+
+	ring, secret, image := newRing(t, 3, 0)
+
+	for _, index := range []int{-1, 3} {
+		if _, err := GenerateRingSignature(keys.Hash{}, image, ring, secret, index); err != ErrInvalidSecretIndex {
+			t.Errorf("index %d: err = %v, want %v", index, err, ErrInvalidSecretIndex)
+		}
+	}
+
+	// y = 2 is not the y coordinate of a point on the curve
+	if _, err := GenerateRingSignature(keys.Hash{}, keys.KeyImage{2}, ring, secret, 0); err != ErrInvalidKeyImage {
+		t.Errorf("err = %v, want %v", err, ErrInvalidKeyImage)
+	}
+
+	if _, err := GenerateRingSignature(keys.Hash{}, image, ring, unreduced, 0); err != keys.ErrInvalidSecretKey {
+		t.Errorf("err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+
+	ring[2] = keys.PublicKey{2}
+
+	if _, err := GenerateRingSignature(keys.Hash{}, image, ring, secret, 0); err != keys.ErrInvalidPublicKey {
+		t.Errorf("err = %v, want %v", err, keys.ErrInvalidPublicKey)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+// Package signatures implements the CryptoNote signature schemes
+package signatures
+
+import (
+	"github.com/turtlecoin/go-turtlecoin/crypto/ed25519"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// Signature is the pair of scalars (c, r)
+type Signature [64]byte
+
+// c returns the challenge half of the signature
+func (s *Signature) c() *[32]byte {
+	return (*[32]byte)(s[:32])
+}
+
+// r returns the response half of the signature
+func (s *Signature) r() *[32]byte {
+	return (*[32]byte)(s[32:])
+}
+
+// commitment is the s_comm struct which is hashed to get the challenge,
+// prefixHash || public || comm
+func commitment(prefixHash keys.Hash, public keys.PublicKey, comm *[32]byte) keys.EllipticCurveScalar {
+	buf := make([]byte, 0, 96)
+
+	buf = append(buf, prefixHash[:]...)
+	buf = append(buf, public[:]...)
+	buf = append(buf, comm[:]...)
+
+	return keys.HashToScalar(buf)
+}
+
+// GenerateSignature signs prefixHash with secret, the secret key of public.
+// With the random scalar k, c = Hs(prefixHash || public || k * B) and
+// r = k - c * secret.
+func GenerateSignature(prefixHash keys.Hash, public keys.PublicKey, secret keys.SecretKey) (Signature, error) {
+	var tmp3 ed25519.ExtendedGroupElement
+	var comm [32]byte
+	var sig Signature
+
+	if !ed25519.ScCheck((*[32]byte)(&secret)) {
+		return Signature{}, keys.ErrInvalidSecretKey
+	}
+
+	k, err := keys.RandomScalar()
+
+	if err != nil {
+		return Signature{}, err
+	}
+
+	ed25519.GeScalarMultBase(&tmp3, (*[32]byte)(&k))
+
+	tmp3.ToBytes(&comm)
+
+	c := commitment(prefixHash, public, &comm)
+
+	copy(sig.c()[:], c[:])
+
+	ed25519.ScMulSub(sig.r(), sig.c(), (*[32]byte)(&secret), (*[32]byte)(&k))
+
+	return sig, nil
+}
+
+// CheckSignature returns true if sig is a valid signature of prefixHash
+// by the secret key of public
+func CheckSignature(prefixHash keys.Hash, public keys.PublicKey, sig Signature) bool {
+	var tmp2 ed25519.ProjectiveGroupElement
+	var tmp3 ed25519.ExtendedGroupElement
+	var comm [32]byte
+	var diff [32]byte
+
+	if !tmp3.FromBytes((*[32]byte)(&public)) {
+		return false
+	}
+
+	if !ed25519.ScCheck(sig.c()) || !ed25519.ScCheck(sig.r()) {
+		return false
+	}
+
+	ed25519.GeDoubleScalarMultBaseVartime(&tmp2, sig.c(), &tmp3, sig.r())
+
+	tmp2.ToBytes(&comm)
+
+	c := commitment(prefixHash, public, &comm)
+
+	ed25519.ScSub(&diff, (*[32]byte)(&c), sig.c())
+
+	return !ed25519.ScIsNonZero(&diff)
+}
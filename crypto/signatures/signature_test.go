@@ -0,0 +1,103 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information
+
+*/
+
+package signatures
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// fromHex decodes s into dst, failing the test if it isn't len(dst) bytes
+// of hex
+func fromHex(t *testing.T, dst []byte, s string) {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+
+	if err != nil || len(b) != len(dst) {
+		t.Fatalf("bad hex %q", s)
+	}
+
+	copy(dst, b)
+}
+
+// The vectors were generated with an independent Python implementation of
+// check_signature and generate_signature from crypto.cpp
+var checkSignatureTests = []struct {
+	name       string
+	prefixHash string
+	public     string
+	sig        string
+	valid      bool
+}{
+	{"valid", "1ad871a639cd5915ff7f54ea9b8a6bd6ba3372fa2b386fc868accaaf9086328b", "811db3b630ba7a4fc50e1b619b3f2500f0d8f51e6a875a04b437f2393367bb25", "303879697480790019e338e403b2c68bc34191295f615b081fb8cdeab9165d0cc14fb333e42b0577646c139feb06e9c29a3da20edcda52987df744a0c6f9b007", true},
+	{"valid", "7748ef9ea82752bedfd8b2f9f7a2bbbbf7ac34ab4703f8c10566ec6ae98c226f", "67238532d8772f3b83450c2ebcb0269cb5ca1945b05840c5c94fd23a4e3ff61b", "789906af28c92d18cdf284a5defc66316f6fd6b7a639522f72135ecd3862bb05ffe984ec3211ba251402063f5634582559d963fb84f14f5670b664018ecc5104", true},
+	{"valid", "07bb31417f0520311cbd2ad808a5c860dd393334a2dee0883ef1553ae1d3c300", "9fc0abef41825fffe76cd165379451e4977ce59626d6b6c5b084c5b635157d5f", "5eec3944a19016fcc7261c803b566d226d8c726f8ce1bd25986a622aa0c9370492a5196ee5ec9cfe48071581a27c5bd31276053ea0aef3f9016baea8ca58a10f", true},
+	{"valid", "4b93e2294968ac1d6f0d60697fc9e506a8536323262b589e7867a583ff165332", "1f727e4505b539bfcd508053b577c13b171a92539e403983c47de36062aeb9c0", "2343590d781c6c3cfb10fd3f65965133a71fd239829ffe687c4053f7d4e26107be00525a0d5f37153ba8d58327afbbaa7d3331fa94f5a1041e63ca832b5ce707", true},
+	{"different prefix hash", "1bd871a639cd5915ff7f54ea9b8a6bd6ba3372fa2b386fc868accaaf9086328b", "811db3b630ba7a4fc50e1b619b3f2500f0d8f51e6a875a04b437f2393367bb25", "303879697480790019e338e403b2c68bc34191295f615b081fb8cdeab9165d0cc14fb333e42b0577646c139feb06e9c29a3da20edcda52987df744a0c6f9b007", false},
+	{"different public key", "1ad871a639cd5915ff7f54ea9b8a6bd6ba3372fa2b386fc868accaaf9086328b", "67238532d8772f3b83450c2ebcb0269cb5ca1945b05840c5c94fd23a4e3ff61b", "303879697480790019e338e403b2c68bc34191295f615b081fb8cdeab9165d0cc14fb333e42b0577646c139feb06e9c29a3da20edcda52987df744a0c6f9b007", false},
+	{"altered r", "1ad871a639cd5915ff7f54ea9b8a6bd6ba3372fa2b386fc868accaaf9086328b", "811db3b630ba7a4fc50e1b619b3f2500f0d8f51e6a875a04b437f2393367bb25", "303879697480790019e338e403b2c68bc34191295f615b081fb8cdeab9165d0cc14fb333e42b0577746c139feb06e9c29a3da20edcda52987df744a0c6f9b007", false},
+	{"c not reduced", "1ad871a639cd5915ff7f54ea9b8a6bd6ba3372fa2b386fc868accaaf9086328b", "811db3b630ba7a4fc50e1b619b3f2500f0d8f51e6a875a04b437f2393367bb25", "1d0c6fc68ee38b58ef7f3087e2aba5a0c34191295f615b081fb8cdeab9165d1cc14fb333e42b0577646c139feb06e9c29a3da20edcda52987df744a0c6f9b007", false},
+	{"r not reduced", "1ad871a639cd5915ff7f54ea9b8a6bd6ba3372fa2b386fc868accaaf9086328b", "811db3b630ba7a4fc50e1b619b3f2500f0d8f51e6a875a04b437f2393367bb25", "303879697480790019e338e403b2c68bc34191295f615b081fb8cdeab9165d0cae23a990fe8e17cf3a090b42ca00c8d79a3da20edcda52987df744a0c6f9b017", false},
+	{"public key not a point", "1ad871a639cd5915ff7f54ea9b8a6bd6ba3372fa2b386fc868accaaf9086328b", "821db3b630ba7a4fc50e1b619b3f2500f0d8f51e6a875a04b437f2393367bb25", "303879697480790019e338e403b2c68bc34191295f615b081fb8cdeab9165d0cc14fb333e42b0577646c139feb06e9c29a3da20edcda52987df744a0c6f9b007", false},
+}
+
+func TestCheckSignature(t *testing.T) {
+	for _, test := range checkSignatureTests {
+		var prefixHash keys.Hash
+		var public keys.PublicKey
+		var sig Signature
+
+		fromHex(t, prefixHash[:], test.prefixHash)
+		fromHex(t, public[:], test.public)
+		fromHex(t, sig[:], test.sig)
+
+		if CheckSignature(prefixHash, public, sig) != test.valid {
+			t.Errorf("%s: CheckSignature = %v, want %v", test.name, !test.valid, test.valid)
+		}
+	}
+}
+
+func TestGenerateSignature(t *testing.T) {
+	public, secret, err := keys.GenerateKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefixHash := keys.Hash{1, 2, 3}
+
+	sig, err := GenerateSignature(prefixHash, public, secret)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !CheckSignature(prefixHash, public, sig) {
+		t.Error("signature does not verify")
+	}
+
+	prefixHash[0]++
+
+	if CheckSignature(prefixHash, public, sig) {
+		t.Error("signature verifies for a different prefix hash")
+	}
+
+	var unreduced keys.SecretKey
+
+	for i := range unreduced {
+		unreduced[i] = 0xff
+	}
+
+	if _, err := GenerateSignature(prefixHash, public, unreduced); err != keys.ErrInvalidSecretKey {
+		t.Errorf("unreduced secret key: err = %v, want %v", err, keys.ErrInvalidSecretKey)
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/turtlecoin/go-turtlecoin
+
+go 1.20
+
+require golang.org/x/text v0.14.0
@@ -0,0 +1,50 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package transaction
+
+import (
+	"github.com/turtlecoin/go-turtlecoin/crypto/keccak"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+)
+
+// hash returns the keccak hash of data
+func hash(data []byte) keys.Hash {
+	var res keys.Hash
+
+	copy(res[:], keccak.Keccak(data, 32))
+
+	return res
+}
+
+// TransactionPrefixHash returns the hash of the binary encoding of the
+// prefix, which the signatures of the inputs sign
+func TransactionPrefixHash(p *TransactionPrefix) (keys.Hash, error) {
+	data, err := p.MarshalBinary()
+
+	if err != nil {
+		return keys.Hash{}, err
+	}
+
+	return hash(data), nil
+}
+
+// TransactionHash returns the hash of the binary encoding of the whole
+// transaction, prefix and signatures, which identifies it on the chain and
+// is a leaf of the merkle root of its block
+func TransactionHash(tx *Transaction) (keys.Hash, error) {
+	data, err := tx.MarshalBinary()
+
+	if err != nil {
+		return keys.Hash{}, err
+	}
+
+	return hash(data), nil
+}
@@ -0,0 +1,112 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package transaction
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/config"
+	"github.com/turtlecoin/go-turtlecoin/varint"
+)
+
+// genesisBlockHash returns the hash of a version 1 genesis block with
+// timestamp 0, nonce and the single transaction txHash. The block hashing
+// blob is the header, the merkle root and the transaction count, and the
+// hash is of its length followed by the blob.
+func genesisBlockHash(txHash [32]byte, nonce uint32) string {
+	blob := []byte{1, 0, 0}
+	blob = append(blob, make([]byte, 32)...)
+	blob = append(blob, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(blob[len(blob)-4:], nonce)
+	// the merkle root of one transaction is its hash
+	blob = append(blob, txHash[:]...)
+	blob = varint.Append(blob, 1)
+
+	h := hash(append(varint.Append(nil, uint64(len(blob))), blob...))
+
+	return hex.EncodeToString(h[:])
+}
+
+func TestTransactionHash(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		tx         string
+		prefixHash string
+		hash       string
+	}{
+		{
+			"TurtleCoin genesis", genesisTransaction,
+			"0d1c0f28b5f5eaa6a21c110eed1339ac9a9eb6a1689d8c31c51a011983069e9b",
+			"0d1c0f28b5f5eaa6a21c110eed1339ac9a9eb6a1689d8c31c51a011983069e9b",
+		},
+		{
+			"Monero genesis", moneroGenesisTransaction,
+			"c88ce9783b4f11190d7b9c17a69c1c52200f9faaee8e98dd07e6811175177139",
+			"c88ce9783b4f11190d7b9c17a69c1c52200f9faaee8e98dd07e6811175177139",
+		},
+		{
+			"signed", signedTransaction,
+			"71898b1b06cc252d8bd1f307e3b09cba4344e0ec26a9248e78bb40d94830a5f4",
+			"dd430d8ad87d1f015b9b3a5c39ef18185c7047fe4b4259a5e586fc3906674c87",
+		},
+		{
+			"Monero block 40646", moneroTransaction,
+			"aeecb4170b276d2ac69a7abca86f82621f56d943c8d4a8900cd56192da8d442d",
+			"ca9ea576d67af4926e31ebeb159aaee58950aea18e5e0ad0bae23b2d85ede8c1",
+		},
+	} {
+		tx := decode(t, test.tx)
+
+		prefixHash, err := TransactionPrefixHash(&tx.TransactionPrefix)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s := hex.EncodeToString(prefixHash[:]); s != test.prefixHash {
+			t.Errorf("%s: prefix hash %s, want %s", test.name, s, test.prefixHash)
+		}
+
+		txHash, err := TransactionHash(tx)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s := hex.EncodeToString(txHash[:]); s != test.hash {
+			t.Errorf("%s: hash %s, want %s", test.name, s, test.hash)
+		}
+	}
+}
+
+// TestGenesisBlockHash checks the hashes of the genesis transactions give
+// the well known hashes of their genesis blocks
+func TestGenesisBlockHash(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		tx    string
+		nonce uint32
+		hash  string
+	}{
+		{"TurtleCoin", config.Mainnet.GenesisCoinbaseTx, config.Mainnet.GenesisNonce, "7fb97df81221dd1366051b2d0bc7f49c66c22ac4431d879c895b06d66ef66f4c"},
+		{"Monero", moneroGenesisTransaction, 10000, "418015bb9ae982a1975da7d79277c2705727a56894ba0fb246adaabb1f4632e3"},
+	} {
+		txHash, err := TransactionHash(decode(t, test.tx))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if h := genesisBlockHash(txHash, test.nonce); h != test.hash {
+			t.Errorf("%s: genesis block hash %s, want %s", test.name, h, test.hash)
+		}
+	}
+}
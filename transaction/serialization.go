@@ -0,0 +1,329 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package transaction
+
+import (
+	"errors"
+
+	"github.com/turtlecoin/go-turtlecoin/crypto/signatures"
+	"github.com/turtlecoin/go-turtlecoin/varint"
+)
+
+// A transaction prefix is encoded as
+//
+//	version (varint) || unlock time (varint) ||
+//	input count (varint) || inputs || output count (varint) || outputs ||
+//	extra length (varint) || extra
+//
+// where each input is a tag followed by
+//
+//	0xff: block index (varint)
+//	0x02: amount (varint) || index count (varint) || indexes (varints) || key image (32)
+//
+// and each output is an amount (varint) followed by a tagged target
+//
+//	0x02: public key (32)
+//
+// A transaction is its prefix followed by the signatures of each input in
+// order, with no counts as they are set by the inputs.
+
+// ErrUnsupportedVersion is returned when a transaction version is newer
+// than CurrentVersion
+var ErrUnsupportedVersion = errors.New("transaction version is not supported")
+
+// ErrUnknownInput is returned when an input has a type which can't be
+// encoded
+var ErrUnknownInput = errors.New("transaction input has an unknown type")
+
+// ErrUnknownOutput is returned when an output target has a type which
+// can't be encoded
+var ErrUnknownOutput = errors.New("transaction output target has an unknown type")
+
+// ErrSignatureCount is returned when a transaction doesn't have the
+// number of signatures its inputs need
+var ErrSignatureCount = errors.New("transaction has the wrong number of signatures")
+
+// ErrTruncated is returned when the data ends part way through a
+// transaction
+var ErrTruncated = errors.New("transaction data is truncated")
+
+// ErrTrailingData is returned when there is data after the end of a
+// transaction
+var ErrTrailingData = errors.New("transaction data continues after the end of the transaction")
+
+// appendPrefix appends the encoding of p to buf
+func appendPrefix(buf []byte, p *TransactionPrefix) ([]byte, error) {
+	if p.Version > CurrentVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	buf = varint.Append(buf, p.Version)
+	buf = varint.Append(buf, p.UnlockTime)
+	buf = varint.Append(buf, uint64(len(p.Inputs)))
+
+	for _, input := range p.Inputs {
+		switch in := input.(type) {
+		case BaseInput:
+			buf = append(buf, baseInputTag)
+			buf = varint.Append(buf, in.BlockIndex)
+		case KeyInput:
+			buf = append(buf, keyInputTag)
+			buf = varint.Append(buf, in.Amount)
+			buf = varint.Append(buf, uint64(len(in.OutputIndexes)))
+
+			for _, index := range in.OutputIndexes {
+				buf = varint.Append(buf, index)
+			}
+
+			buf = append(buf, in.KeyImage[:]...)
+		default:
+			return nil, ErrUnknownInput
+		}
+	}
+
+	buf = varint.Append(buf, uint64(len(p.Outputs)))
+
+	for _, output := range p.Outputs {
+		buf = varint.Append(buf, output.Amount)
+
+		switch target := output.Target.(type) {
+		case KeyOutput:
+			buf = append(buf, keyOutputTag)
+			buf = append(buf, target.Key[:]...)
+		default:
+			return nil, ErrUnknownOutput
+		}
+	}
+
+	buf = varint.Append(buf, uint64(len(p.Extra)))
+
+	return append(buf, p.Extra...), nil
+}
+
+// MarshalBinary returns the binary encoding of the transaction prefix
+func (p *TransactionPrefix) MarshalBinary() ([]byte, error) {
+	return appendPrefix(nil, p)
+}
+
+// MarshalBinary returns the binary encoding of the transaction, as it is
+// sent between nodes and stored in blocks
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	buf, err := appendPrefix(nil, &tx.TransactionPrefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tx.Signatures) != 0 && len(tx.Signatures) != len(tx.Inputs) {
+		return nil, ErrSignatureCount
+	}
+
+	for i, input := range tx.Inputs {
+		count := input.signatureCount()
+
+		if len(tx.Signatures) == 0 {
+			if count != 0 {
+				return nil, ErrSignatureCount
+			}
+
+			continue
+		}
+
+		if len(tx.Signatures[i]) != count {
+			return nil, ErrSignatureCount
+		}
+
+		for _, sig := range tx.Signatures[i] {
+			buf = append(buf, sig[:]...)
+		}
+	}
+
+	return buf, nil
+}
+
+// decoder reads from the start of buf, and stops at the first error
+type decoder struct {
+	buf []byte
+	err error
+}
+
+// varint reads a varint
+func (d *decoder) varint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	n, size, err := varint.Decode(d.buf)
+
+	if err == varint.ErrTruncated {
+		err = ErrTruncated
+	}
+
+	if err != nil {
+		d.err = err
+		return 0
+	}
+
+	d.buf = d.buf[size:]
+
+	return n
+}
+
+// count reads the number of items in a list, each at least size bytes
+// long, so a corrupt count can't cause a huge allocation
+func (d *decoder) count(size int) int {
+	n := d.varint()
+
+	if d.err == nil && n > uint64(len(d.buf)/size) {
+		d.err = ErrTruncated
+		return 0
+	}
+
+	return int(n)
+}
+
+// read copies the next len(dst) bytes into dst
+func (d *decoder) read(dst []byte) {
+	if d.err != nil {
+		return
+	}
+
+	if len(d.buf) < len(dst) {
+		d.err = ErrTruncated
+		return
+	}
+
+	d.buf = d.buf[copy(dst, d.buf):]
+}
+
+// byte reads one byte
+func (d *decoder) byte() byte {
+	var b [1]byte
+
+	d.read(b[:])
+
+	return b[0]
+}
+
+// prefix reads a transaction prefix into p
+func (d *decoder) prefix(p *TransactionPrefix) {
+	p.Version = d.varint()
+
+	if d.err == nil && p.Version > CurrentVersion {
+		d.err = ErrUnsupportedVersion
+		return
+	}
+
+	p.UnlockTime = d.varint()
+	p.Inputs = make([]Input, d.count(1))
+
+	for i := range p.Inputs {
+		switch d.byte() {
+		case baseInputTag:
+			p.Inputs[i] = BaseInput{BlockIndex: d.varint()}
+		case keyInputTag:
+			var in KeyInput
+
+			in.Amount = d.varint()
+			in.OutputIndexes = make([]uint64, d.count(1))
+
+			for j := range in.OutputIndexes {
+				in.OutputIndexes[j] = d.varint()
+			}
+
+			d.read(in.KeyImage[:])
+
+			p.Inputs[i] = in
+		default:
+			if d.err == nil {
+				d.err = ErrUnknownInput
+			}
+		}
+
+		if d.err != nil {
+			return
+		}
+	}
+
+	p.Outputs = make([]Output, d.count(1))
+
+	for i := range p.Outputs {
+		p.Outputs[i].Amount = d.varint()
+
+		switch d.byte() {
+		case keyOutputTag:
+			var target KeyOutput
+
+			d.read(target.Key[:])
+
+			p.Outputs[i].Target = target
+		default:
+			if d.err == nil {
+				d.err = ErrUnknownOutput
+			}
+		}
+
+		if d.err != nil {
+			return
+		}
+	}
+
+	p.Extra = make([]byte, d.count(1))
+
+	d.read(p.Extra)
+}
+
+// end returns the first error, or ErrTrailingData if there is data left
+func (d *decoder) end() error {
+	if d.err == nil && len(d.buf) != 0 {
+		return ErrTrailingData
+	}
+
+	return d.err
+}
+
+// UnmarshalBinary decodes a transaction prefix, which must be all of data
+func (p *TransactionPrefix) UnmarshalBinary(data []byte) error {
+	d := decoder{buf: data}
+
+	d.prefix(p)
+
+	return d.end()
+}
+
+// UnmarshalBinary decodes a transaction, which must be all of data
+func (tx *Transaction) UnmarshalBinary(data []byte) error {
+	d := decoder{buf: data}
+
+	d.prefix(&tx.TransactionPrefix)
+
+	if d.err != nil {
+		return d.err
+	}
+
+	tx.Signatures = make([][]signatures.Signature, len(tx.Inputs))
+
+	for i, input := range tx.Inputs {
+		count := input.signatureCount()
+
+		if count > len(d.buf)/len(signatures.Signature{}) {
+			return ErrTruncated
+		}
+
+		tx.Signatures[i] = make([]signatures.Signature, count)
+
+		for j := range tx.Signatures[i] {
+			d.read(tx.Signatures[i][j][:])
+		}
+	}
+
+	return d.end()
+}
@@ -0,0 +1,108 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+// Package transaction implements TurtleCoin transactions and their binary
+// encoding, the Go equivalent of the transaction parts of
+// CryptoNoteBasic.h and CryptoNoteSerialization.cpp
+package transaction
+
+import (
+	"github.com/turtlecoin/go-turtlecoin/config"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/crypto/signatures"
+)
+
+// CurrentVersion is the newest transaction version, later versions can't
+// be decoded
+const CurrentVersion = config.CurrentTransactionVersion
+
+// Input tags, the byte before each input in the binary encoding
+const (
+	baseInputTag = 0xff
+	keyInputTag  = 0x02
+)
+
+// Output target tags, the byte before each output target
+const (
+	keyOutputTag = 0x02
+)
+
+// Input is an input of a transaction, a BaseInput or a KeyInput
+type Input interface {
+	// signatureCount is the number of signatures the input is signed with
+	signatureCount() int
+}
+
+// BaseInput is the input of a coinbase transaction, which creates the
+// block reward
+type BaseInput struct {
+	// BlockIndex is the height of the block the transaction is in
+	BlockIndex uint64
+}
+
+// KeyInput spends one of a ring of outputs, without saying which
+type KeyInput struct {
+	Amount uint64
+	// OutputIndexes are the global indexes of the outputs in the ring,
+	// among outputs of the same amount. The first is absolute, the rest
+	// are relative to the one before, as they are stored on the chain.
+	OutputIndexes []uint64
+	// KeyImage is the key image of the output being spent, which stops it
+	// being spent twice
+	KeyImage keys.KeyImage
+}
+
+func (BaseInput) signatureCount() int {
+	return 0
+}
+
+func (in KeyInput) signatureCount() int {
+	return len(in.OutputIndexes)
+}
+
+// OutputTarget is who can spend an output, a KeyOutput
+type OutputTarget interface {
+	isOutputTarget()
+}
+
+// KeyOutput is an output which can be spent with the secret key of Key
+type KeyOutput struct {
+	Key keys.PublicKey
+}
+
+func (KeyOutput) isOutputTarget() {}
+
+// Output is an output of a transaction
+type Output struct {
+	Amount uint64
+	Target OutputTarget
+}
+
+// TransactionPrefix is the part of a transaction its signatures sign
+type TransactionPrefix struct {
+	Version uint64
+	// UnlockTime is the height, or unix time if at least
+	// 500000000, until which the outputs can't be spent
+	UnlockTime uint64
+	Inputs     []Input
+	Outputs    []Output
+	// Extra holds the transaction public key, payment ID and other tagged
+	// fields
+	Extra []byte
+}
+
+// Transaction is a transaction prefix and its signatures
+type Transaction struct {
+	TransactionPrefix
+	// Signatures holds the ring signature of each input, with one
+	// signature per output in the ring and none for a BaseInput. It may
+	// be empty if no input needs a signature.
+	Signatures [][]signatures.Signature
+}
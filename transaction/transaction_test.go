@@ -0,0 +1,347 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/turtlecoin/go-turtlecoin/config"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/crypto/signatures"
+)
+
+// genesisTransaction is the coinbase transaction of the TurtleCoin
+// genesis block
+var genesisTransaction = config.Mainnet.GenesisCoinbaseTx
+
+// moneroGenesisTransaction is the coinbase transaction of the Monero
+// genesis block
+const moneroGenesisTransaction = "013c01ff0001ffffffffffff03029b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd08807121017767aafcde9be00dcfd098715ebcf7f410daebc582fda69d24a28e9d0bc890d1"
+
+// moneroTransaction is transaction
+// ca9ea576d67af4926e31ebeb159aaee58950aea18e5e0ad0bae23b2d85ede8c1 of
+// Monero mainnet block 40646, from the tests of moneroutil. It is a
+// version 1 transaction in the same format as TurtleCoin's, spending 17
+// key inputs with rings of one output to 6 outputs.
+const moneroTransaction = "01001102809bee0201d11fc9679ba9ca8a6fa87a1352985e46ea3723489d3699ab1af075532f711739b9c50280b4c4c32101c6210e99a2f46b383802f04a7f231047a2ed414e861b1d2a3494b371c04759cc270d028095f52a01882074b213379239558f5d4628a48a43a36ce9cdbe673453608719836664f7e809490280c0a8ca9a3a01b102f61c2293448c98e77ccf1165638e908986de0789917fd291f9bc32491360360b0280897a01d12004d0d8575cdde390173e269f61e8edb9406a76e479106987adc714c14f4530150280d88ee16f01eb1d58dc8327b8c0b944b05024863ecd64bceac940001e93e3acabfc570bff5483620280b4c4c32101bd204b8ff554752b31af4ad50f7edcaec088b4f7ee2693a59661c06890119fc48121028090bcfd0201cf1fa1b66ceefb3af10b0184798a78a3a26d0bd1d762366927540fb053634a8b97b00280b09dc2df0101a6239388ec3806bf2f3997d76f7e05820330068598deea35dd8eefcc31fced30b8670280d293ad0301b92045905146e79364df9fffa56da7fa37e1534acaeaac951cc6d754516c5b808510028080a2a9eae80101880147e6864c2c086c13dcde36e360e3adcc3bbe295a893b9f6183020e3da1920e8a0280b4c4c32101b620f1ea44fc891f7d5ea914abefd964f46147eb650585825731e6ecbd33e469952c0280b081daaf1401aa0222f06bb1b9ec96b84763f07c554469b8c2d9ae7bae97ccb7602a960302bcf69b02809bee0201d51ea044522fb61ddfc366666b9de517d2901c3b821ffb1aa09e8932dad3f2e4272702809bee0201d61e1253cf28f88eaa38cce28d43d6d076780e1d85b14eeccb65b76f6b79d6e079a00280d0b8e1981a01b810a06b60e2069a79706e442cb60b9c1c66d018a9222dd03205527c9ab94c2a2053028088aca3cf0201812194f83724e913891d5add7d7a4256f17a9fd76fb91f6600289bcc42e83ccdb79e06c0a8a50402eebdccc569747e7ad5787d1a88f7b67dd753b86b03c331bc93dbbcffd413ee368090bcfd0202d5d6274573883a2a1231b15701e2447e4c5ad3f4a6901701ea1ab477ba8d502e80a0d9e61d029c6a48ca222ac5b4f037828166b95929b766b8d71582ddf8dbc718983e6626b88080dd9da4170250ec4b429b04fa717bab4c315660862137848064536f9a02bb1d4e5894e36f3380a094a58d1d0200197ee25626aa3cc0adc1c372ce8e58c5a3f3cb97f341412306cdbf1a5f25e380c0caf384a30202ebc842713e4bd0124917c34c361d2b31dd2343db91c24e44a23653d800aa2199440221003fe2d8b0f49996be3fdf4bc732ad0fda3fde42488bd9a6dc3fef018c4b77aa53015f641367cb5d2c4c40f5b7dc726ce1ac651623b8082746221e468a47c46556cd11b4d1bd92e85f38152848cbf100c6f8b15c9de5278e4506bb9131230807d60e658188593715e7980a9d9e188d2114f2a3b71541cfe66fb94413237edf36dc0aa15a56d12471a2cf2e25be43a3ee48571602dd19ebf4e4e266aec5fe3102650e1a60e1343243d80083df37004a1f2854276d3c4c0f2aca56c39ae7b1d254180036f40c9af0528811fea9037b5d622f8c8c35df908e1603e3ba6e68ce8cb75809f6d08eb841a31d9ba3431c67449cef0892347c1e04c69dec0e09d29b522ac50bab5482839d1cb11a87f7725fe5e8783becb0ea0fa72a78de4971c0322b7923042d8160d8328228ba25a2da542c2405317f5bb5e1a2c9bb5feb46c9c2b037d40ab1c98c70cb8734070bf96fd84125180b8b89906335eab6b09e3ca0a5687c9807fd24038e8ae0e7eaacae4c5bfbaaaea58aad3b0d412bf1018232826eafc40c00eb495e5cb3471025744818fcf6b816dd817f64d5eea8947f847e180bf8607e068e3ea474372d1fa6403b936841f53ec3de8b46c844f2e448e32d64c6767c4909938010d942fe8443326196ce93046d99a0c215182d3db9ede168e28380833002e78a7366b2150946ec2bf63796dd978fad325236c97ab7141b8dcec316b07a0269ccff03c6b402f4f9403f88b00b778f8468ec1e00a8528492b49a8b7cfa6e042ce6cde5341618d5f6a019db385644c598332dcd68e4f16166af3a6c824a8801ea768e60ff37038e78958e68b423c5510c98d86482af42c1c1df33a967ab8c0a6cfd56986640333b75f0ff31922d5ff70829799340cc0d18f494f7a38a27b30b099b882b67da4fb48e245c6456241c19eff98f066c0fe1d7a0995b3b5e14de0cdf2ac0f8ffe135d0710b7854df42f990ffc9bf260a4656a51aadb64eecfd6600df7aad92c9fc0d6d377e05ab10286cf86501b77929bb2c4939dc88b1b0001e0f040d0a1260b8f38d2f68a7052267d43e389bb0365982e63c6e347c8c4109430fff86011fa605ff377f4c626c7178f1f5938da6305730e52ee19bfc4335ce87001e90adda866adfbbd662376871fdb9680b2d03c0b43a4195e9e3a1111c24c40c25e8cb88df2f983fbd0894513bd50d7f6ab17e3ec3d93a9dd5d0dff27e6110038b5c05dd084dd957eef2d9fb0de7ca67d75e1b8a81b10dc3edf099a020536f0cfd6a1a7294ab1db71d78486324d2e7baf84147e304ba450d07ae6b97624dd9009bc3a3d857d80c2af5729707522f5fca14a07deaa73e16b2429b0a5d35a8970b0dadafdcd67834f0241443b935d57f77cd4cf6f309235e71c987c9a5bb15110818f4d46aa56d6c55dcb07b49b899d617be4237b98282e2dfbff86365c3d532077f7fa7550dc29c79419410132dc1a2f2f0598212b483fa59d56ecbc662c09e0d3d944ad18d9a5a55ec9b8af82a1d148f0677de4526d26ce7fe123bdd809c0c06f60b6cceb8c694da13a8ac807258a8dc45368fde5b46e02947de10b13dab5b035a9490369ee32e2917fa00965c03592e5c32489b21400741479d9451aac8d708"
+
+// moneroRing is the public key of the output in the ring of the first
+// input of moneroTransaction, and moneroSignature its ring signature
+const (
+	moneroRing      = "6646f168c842275b31ca863f6eac8eed9e5dfc5714d5864efb62f6c340298a30"
+	moneroSignature = "11b4d1bd92e85f38152848cbf100c6f8b15c9de5278e4506bb9131230807d60e658188593715e7980a9d9e188d2114f2a3b71541cfe66fb94413237edf36dc0a"
+)
+
+// signedTransaction spends two inputs, with rings of 3 and 2 outputs, to
+// two outputs. Its rings are signedRings.
+const signedTransaction = "01000202b0ea0103b70905707252a5d9b2866c725cd868bfd4405c53e76692be22ce650e23f2a4414eb8111d02d00f023003b9cc2e5b9f554e927501a5c860871cc2dcad37ebc88b220ef06bc0f0d2d2b26602a09c0102177348e0da0b4732802bd6e7a06e64a13c25c2810ca0e54bf0b6f44361c291fad00f022713f3579874842b811e78def88d55b93da9ea55c750d1b2eca766b221d09ab721011cc293010e35edf28ccecd46f08737071df28717d6867f8956846cceb98c17086b2dcb57b70fe921a8b9a743110103b39430a7352689200566801e527eec58023a2bcbd2b635e495e201b8bb56d79851c86285ee94b657f836b94b6c995545002d74a6dda2c07b94012f3a94236ecfa0e85e0d9f08f05908bb834aa9e05b54099f3eb26d417abe42605b9bfdf118eeaba3e635c297c5c2d4d364d7af3447610c84cf50c422de88961d6ee62d4005405018f5cc39ad6110a93e000bbbfd707d02a1cf0a2b003acda34122613e654e6599026fe2733afe0f58799e103be636fb0930ed14e84f5ba076bd537a017887311a9a2a2ba9da0aca18cea7f49daa45f50d197d85e4e04654f213ae092dd8723139d7034399e5aa9643859638e8c4a3c107ed3c14843fcc15ad56ea9b2198f786675e491f539445495bbdd223eb31ef8a054b253c6c7e017f29276a08879042a98954be2e535cbfb699f03a7ad4cde07801"
+
+// signedPrefixLength is the length of the prefix of signedTransaction
+const signedPrefixLength = 188
+
+// signedRings are the public keys of the outputs in the ring of each
+// input of signedTransaction
+var signedRings = [][]string{
+	{
+		"78552ba0efabceb78de095a82250f310812bf30318f5f3a038b5f38f41470ddc",
+		"bec57d22656391795b9fa1c6b43f7cc99bd73ed411712e93ede1d8d1e40d8104",
+		"37a69e22d1260752c48906e6c2496ffffeb536aa792cc310b63ddb711b315d36",
+	},
+	{
+		"ee04ba5680fc41a721ae4d33eb56dc0dd7c0d7702bce8a10152354389c1beb3f",
+		"a2b08f9a26653bafa40ba47110b3723909d8f08e430d2ec76877629792bcb350",
+	},
+}
+
+func fromHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	res, err := hex.DecodeString(s)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func publicKey(t *testing.T, s string) keys.PublicKey {
+	t.Helper()
+
+	var res keys.PublicKey
+
+	copy(res[:], fromHex(t, s))
+
+	return res
+}
+
+// decode decodes a transaction and checks it encodes back to the same
+// bytes
+func decode(t *testing.T, s string) *Transaction {
+	t.Helper()
+
+	data := fromHex(t, s)
+
+	var tx Transaction
+
+	if err := tx.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := tx.MarshalBinary()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(encoded, data) {
+		t.Fatalf("encoded as %x, want %s", encoded, s)
+	}
+
+	return &tx
+}
+
+func TestCoinbase(t *testing.T) {
+	for _, test := range []struct {
+		tx         string
+		unlockTime uint64
+		amount     uint64
+	}{
+		{genesisTransaction, 10, 2980232},
+		{moneroGenesisTransaction, 60, 17592186044415},
+	} {
+		tx := decode(t, test.tx)
+
+		if tx.Version != 1 || tx.UnlockTime != test.unlockTime {
+			t.Errorf("version %d, unlock time %d, want 1, %d", tx.Version, tx.UnlockTime, test.unlockTime)
+		}
+
+		if len(tx.Inputs) != 1 || tx.Inputs[0] != (BaseInput{BlockIndex: 0}) {
+			t.Errorf("inputs %v, want the base input of block 0", tx.Inputs)
+		}
+
+		if len(tx.Outputs) != 1 || tx.Outputs[0].Amount != test.amount {
+			t.Errorf("outputs %v, want one of %d", tx.Outputs, test.amount)
+		}
+
+		// the extra is the tag of the transaction public key and the key
+		if len(tx.Extra) != 33 || tx.Extra[0] != 0x01 {
+			t.Errorf("extra %x, want a transaction public key", tx.Extra)
+		}
+
+		if len(tx.Signatures) != 1 || len(tx.Signatures[0]) != 0 {
+			t.Errorf("signatures %v, want none", tx.Signatures)
+		}
+	}
+}
+
+func TestSignedTransaction(t *testing.T) {
+	tx := decode(t, signedTransaction)
+
+	wantInputs := []struct {
+		amount  uint64
+		indexes []uint64
+	}{
+		{30000, []uint64{1207, 5, 112}},
+		{2000, []uint64{48, 3}},
+	}
+
+	if len(tx.Inputs) != len(wantInputs) {
+		t.Fatalf("%d inputs, want %d", len(tx.Inputs), len(wantInputs))
+	}
+
+	prefixHash, err := TransactionPrefixHash(&tx.TransactionPrefix)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range wantInputs {
+		in, ok := tx.Inputs[i].(KeyInput)
+
+		if !ok || in.Amount != want.amount || len(in.OutputIndexes) != len(want.indexes) {
+			t.Fatalf("input %d is %v, want amount %d and indexes %v", i, tx.Inputs[i], want.amount, want.indexes)
+		}
+
+		for j, index := range want.indexes {
+			if in.OutputIndexes[j] != index {
+				t.Errorf("input %d: indexes %v, want %v", i, in.OutputIndexes, want.indexes)
+				break
+			}
+		}
+
+		ring := make([]keys.PublicKey, len(signedRings[i]))
+
+		for j, key := range signedRings[i] {
+			ring[j] = publicKey(t, key)
+		}
+
+		if !signatures.CheckRingSignature(prefixHash, in.KeyImage, ring, tx.Signatures[i]) {
+			t.Errorf("input %d: ring signature is invalid", i)
+		}
+	}
+
+	for i, amount := range []uint64{20000, 2000} {
+		if tx.Outputs[i].Amount != amount {
+			t.Errorf("output %d: amount %d, want %d", i, tx.Outputs[i].Amount, amount)
+		}
+	}
+
+	prefix, err := tx.TransactionPrefix.MarshalBinary()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(prefix, fromHex(t, signedTransaction)[:signedPrefixLength]) {
+		t.Errorf("prefix encoded as %x", prefix)
+	}
+}
+
+// TestMoneroTransaction checks a transaction from a live network decodes
+// and its first ring signature is valid
+func TestMoneroTransaction(t *testing.T) {
+	tx := decode(t, moneroTransaction)
+
+	if tx.Version != 1 || tx.UnlockTime != 0 || len(tx.Inputs) != 17 || len(tx.Outputs) != 6 || len(tx.Signatures) != 17 {
+		t.Fatalf("version %d, unlock time %d, %d inputs, %d outputs, %d signatures, want 1, 0, 17, 6, 17",
+			tx.Version, tx.UnlockTime, len(tx.Inputs), len(tx.Outputs), len(tx.Signatures))
+	}
+
+	var inputs, outputs uint64
+
+	for i, in := range tx.Inputs {
+		key, ok := in.(KeyInput)
+
+		if !ok || len(key.OutputIndexes) != 1 || len(tx.Signatures[i]) != 1 {
+			t.Fatalf("input %d is %v with %d signatures, want a key input with a ring of one", i, in, len(tx.Signatures[i]))
+		}
+
+		inputs += key.Amount
+	}
+
+	for _, out := range tx.Outputs {
+		outputs += out.Amount
+	}
+
+	// the difference is the fee
+	if inputs != 11808810000000 || outputs != 11808809000000 {
+		t.Errorf("inputs of %d, outputs of %d, want 11808810000000, 11808809000000", inputs, outputs)
+	}
+
+	in := tx.Inputs[0].(KeyInput)
+
+	if s := hex.EncodeToString(in.KeyImage[:]); s != "c9679ba9ca8a6fa87a1352985e46ea3723489d3699ab1af075532f711739b9c5" {
+		t.Errorf("key image %s", s)
+	}
+
+	if s := hex.EncodeToString(tx.Signatures[0][0][:]); s != moneroSignature {
+		t.Errorf("signature %s, want %s", s, moneroSignature)
+	}
+
+	prefixHash, err := TransactionPrefixHash(&tx.TransactionPrefix)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ring := []keys.PublicKey{publicKey(t, moneroRing)}
+
+	if !signatures.CheckRingSignature(prefixHash, in.KeyImage, ring, tx.Signatures[0]) {
+		t.Error("ring signature is invalid")
+	}
+
+	// the signature doesn't sign another prefix
+	prefixHash[0] ^= 1
+
+	if signatures.CheckRingSignature(prefixHash, in.KeyImage, ring, tx.Signatures[0]) {
+		t.Error("ring signature is valid for another prefix")
+	}
+}
+
+// TestTruncated checks every strict prefix of each transaction fails to
+// decode
+func TestTruncated(t *testing.T) {
+	for _, s := range []string{genesisTransaction, moneroGenesisTransaction, signedTransaction, moneroTransaction} {
+		data := fromHex(t, s)
+
+		for n := 0; n < len(data); n++ {
+			var tx Transaction
+
+			if err := tx.UnmarshalBinary(data[:n]); err != ErrTruncated {
+				t.Errorf("%d of %d bytes: err = %v, want %v", n, len(data), err, ErrTruncated)
+			}
+		}
+	}
+}
+
+func TestTrailingData(t *testing.T) {
+	for _, s := range []string{genesisTransaction, moneroGenesisTransaction, signedTransaction, moneroTransaction} {
+		var tx Transaction
+
+		if err := tx.UnmarshalBinary(append(fromHex(t, s), 0)); err != ErrTrailingData {
+			t.Errorf("err = %v, want %v", err, ErrTrailingData)
+		}
+	}
+
+	// the signatures follow the prefix
+	var p TransactionPrefix
+
+	if err := p.UnmarshalBinary(fromHex(t, signedTransaction)); err != ErrTrailingData {
+		t.Errorf("prefix: err = %v, want %v", err, ErrTrailingData)
+	}
+
+	if err := p.UnmarshalBinary(fromHex(t, signedTransaction)[:signedPrefixLength]); err != nil {
+		t.Errorf("prefix: err = %v", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"version 2", "02" + genesisTransaction[2:], ErrUnsupportedVersion},
+		// the input tag of the genesis transaction is the 4th byte
+		{"input tag", genesisTransaction[:6] + "03" + genesisTransaction[8:], ErrUnknownInput},
+		// the output target tag is the 11th byte, after the 4 byte amount
+		{"output tag", genesisTransaction[:20] + "03" + genesisTransaction[22:], ErrUnknownOutput},
+	} {
+		var tx Transaction
+
+		if err := tx.UnmarshalBinary(fromHex(t, test.data)); err != test.err {
+			t.Errorf("%s: err = %v, want %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	tx := decode(t, signedTransaction)
+
+	tx.Signatures[1] = tx.Signatures[1][1:]
+
+	if _, err := tx.MarshalBinary(); err != ErrSignatureCount {
+		t.Errorf("short ring signature: err = %v, want %v", err, ErrSignatureCount)
+	}
+
+	tx.Signatures = nil
+
+	if _, err := tx.MarshalBinary(); err != ErrSignatureCount {
+		t.Errorf("no signatures: err = %v, want %v", err, ErrSignatureCount)
+	}
+
+	tx.Version = CurrentVersion + 1
+
+	if _, err := tx.MarshalBinary(); err != ErrUnsupportedVersion {
+		t.Errorf("version %d: err = %v, want %v", tx.Version, err, ErrUnsupportedVersion)
+	}
+}
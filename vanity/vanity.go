@@ -0,0 +1,356 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+// Package vanity searches for addresses which start with a chosen prefix.
+// The view key of each address is derived from the spend key, as the
+// wallets do, so the addresses found can be restored from a normal 25
+// word mnemonic seed.
+package vanity
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/turtlecoin/go-turtlecoin/address"
+	"github.com/turtlecoin/go-turtlecoin/base58"
+	"github.com/turtlecoin/go-turtlecoin/config"
+	"github.com/turtlecoin/go-turtlecoin/crypto/keys"
+	"github.com/turtlecoin/go-turtlecoin/varint"
+)
+
+// ErrInvalidCharacter is returned when the prefix contains a character
+// which can't appear in an address
+var ErrInvalidCharacter = errors.New("prefix contains a character which is not in the base58 alphabet")
+
+// ErrPrefixTooLong is returned when the prefix is longer than
+// MaxPrefixLength allows
+var ErrPrefixTooLong = errors.New("prefix is too long")
+
+// ErrImpossiblePrefix is returned when no address on the network can
+// start with the prefix, usually because it doesn't start with the
+// characters every address starts with
+var ErrImpossiblePrefix = errors.New("no address on the network starts with the prefix")
+
+// Result is an address found by Search
+type Result struct {
+	Address     address.Address
+	SpendSecret keys.SecretKey
+	ViewSecret  keys.SecretKey
+	// Attempts is the number of keys tried by all the workers
+	Attempts uint64
+}
+
+// Progress is passed to Options.Progress while searching
+type Progress struct {
+	// Attempts is the number of keys tried so far
+	Attempts uint64
+	// Elapsed is the time since the search started
+	Elapsed time.Duration
+	// Rate is the number of keys tried per second
+	Rate float64
+	// Expected is the average number of keys which must be tried to find
+	// a match
+	Expected float64
+	// Remaining is the expected time until a match is found, each key is
+	// independent so this doesn't go down as the search goes on
+	Remaining time.Duration
+}
+
+// Options change how Search works
+type Options struct {
+	// Network is the network of the addresses, Mainnet if nil
+	Network *config.NetworkParameters
+	// Workers is the number of goroutines to search with, one per CPU if
+	// 0 or less
+	Workers int
+	// Progress, if not nil, is called every ProgressInterval
+	Progress func(Progress)
+	// ProgressInterval is the time between calls to Progress, one second
+	// if 0 or less
+	ProgressInterval time.Duration
+}
+
+// FixedPrefix returns the characters every address on network starts
+// with, which are set by the address prefix alone
+func FixedPrefix(network *config.NetworkParameters) string {
+	var low, high keys.PublicKey
+
+	for i := range high {
+		high[i] = 0xff
+	}
+
+	a := address.NewForNetwork(network, low, low).String()
+	b := address.NewForNetwork(network, high, high).String()
+
+	i := 0
+
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return a[:i]
+}
+
+// MaxPrefixLength returns the length of the longest prefix which can be
+// searched for on network. Only the characters which depend on the
+// address prefix and the spend key alone can be searched for, so the view
+// key is only derived for matches.
+func MaxPrefixLength(network *config.NetworkParameters) int {
+	return (varint.Len(network.AddressPrefix) + 32) / base58.BlockSize * base58.EncodedBlockSize
+}
+
+// ExpectedAttempts returns the average number of keys which must be tried
+// to find an address starting with prefix on network, or an error if no
+// address can start with it
+func ExpectedAttempts(network *config.NetworkParameters, prefix string) (float64, error) {
+	if len(prefix) > MaxPrefixLength(network) {
+		return 0, ErrPrefixTooLong
+	}
+
+	for i := 0; i < len(prefix); i++ {
+		if strings.IndexByte(base58.Alphabet, prefix[i]) < 0 {
+			return 0, ErrInvalidCharacter
+		}
+	}
+
+	fixed := varint.Encode(network.AddressPrefix)
+	res := new(big.Float).SetInt64(1)
+
+	for i := 0; i < len(prefix); i += base58.EncodedBlockSize {
+		end := i + base58.EncodedBlockSize
+
+		if end > len(prefix) {
+			end = len(prefix)
+		}
+
+		// the range of values of this block, which starts with the
+		// bytes of the address prefix still left, if any
+		var lo, hi [base58.BlockSize]byte
+
+		n := copy(lo[:], fixed)
+
+		copy(hi[:], fixed)
+
+		for j := n; j < base58.BlockSize; j++ {
+			hi[j] = 0xff
+		}
+
+		fixed = fixed[n:]
+
+		p := blockProbability(new(big.Int).SetBytes(lo[:]), new(big.Int).SetBytes(hi[:]), prefix[i:end])
+
+		if p.Sign() == 0 {
+			return 0, ErrImpossiblePrefix
+		}
+
+		res.Mul(res, p)
+	}
+
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt64(1), res).Float64()
+
+	return f, nil
+}
+
+// blockProbability returns the fraction of the values from lo to hi which
+// are encoded in characters starting with prefix
+func blockProbability(lo, hi *big.Int, prefix string) *big.Float {
+	// the range of values whose encoding starts with prefix
+	first := decodePadded(prefix, base58.Alphabet[0])
+	last := decodePadded(prefix, base58.Alphabet[len(base58.Alphabet)-1])
+
+	if first.Cmp(lo) < 0 {
+		first = lo
+	}
+
+	if last.Cmp(hi) > 0 {
+		last = hi
+	}
+
+	matches := new(big.Int).Sub(last, first)
+	total := new(big.Int).Sub(hi, lo)
+
+	if matches.Sign() < 0 {
+		return new(big.Float)
+	}
+
+	matches.Add(matches, big.NewInt(1))
+	total.Add(total, big.NewInt(1))
+
+	return new(big.Float).Quo(new(big.Float).SetInt(matches), new(big.Float).SetInt(total))
+}
+
+// decodePadded returns the value of a block encoded as prefix followed by
+// pad up to the full length of a block
+func decodePadded(prefix string, pad byte) *big.Int {
+	res := new(big.Int)
+	base := big.NewInt(int64(len(base58.Alphabet)))
+
+	for i := 0; i < base58.EncodedBlockSize; i++ {
+		c := pad
+
+		if i < len(prefix) {
+			c = prefix[i]
+		}
+
+		res.Mul(res, base)
+		res.Add(res, big.NewInt(int64(strings.IndexByte(base58.Alphabet, c))))
+	}
+
+	return res
+}
+
+// Search generates keys until it finds an address which starts with
+// prefix, or ctx is done, in which case it returns ctx.Err().
+func Search(ctx context.Context, prefix string, opts Options) (*Result, error) {
+	network := opts.Network
+
+	if network == nil {
+		network = config.Mainnet
+	}
+
+	expected, err := ExpectedAttempts(network, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workers := opts.Workers
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var attempts uint64
+	var once sync.Once
+	var result *Result
+	var resultErr error
+	var wg sync.WaitGroup
+
+	finish := func(r *Result, err error) {
+		once.Do(func() {
+			result, resultErr = r, err
+			cancel()
+		})
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				spendPublic, spendSecret, err := keys.GenerateKeys()
+
+				if err != nil {
+					finish(nil, err)
+					return
+				}
+
+				atomic.AddUint64(&attempts, 1)
+
+				// the view key doesn't change the first
+				// MaxPrefixLength characters
+				candidate := address.NewForNetwork(network, spendPublic, keys.PublicKey{})
+
+				if !strings.HasPrefix(candidate.String(), prefix) {
+					continue
+				}
+
+				viewPublic, viewSecret, err := keys.GenerateViewFromSpend(spendSecret)
+
+				if err != nil {
+					finish(nil, err)
+					return
+				}
+
+				finish(&Result{
+					Address:     address.NewForNetwork(network, spendPublic, viewPublic),
+					SpendSecret: spendSecret,
+					ViewSecret:  viewSecret,
+				}, nil)
+
+				return
+			}
+		}()
+	}
+
+	// the workers only stop once ctx is done, which stops reportProgress
+	// too, so Progress is never called after Search returns
+	progressDone := make(chan struct{})
+
+	if opts.Progress != nil {
+		go func() {
+			defer close(progressDone)
+
+			reportProgress(ctx, &opts, &attempts, expected)
+		}()
+	} else {
+		close(progressDone)
+	}
+
+	wg.Wait()
+	<-progressDone
+
+	if result != nil {
+		result.Attempts = atomic.LoadUint64(&attempts)
+
+		return result, nil
+	}
+
+	if resultErr != nil {
+		return nil, resultErr
+	}
+
+	return nil, ctx.Err()
+}
+
+// reportProgress calls opts.Progress every opts.ProgressInterval until ctx
+// is done
+func reportProgress(ctx context.Context, opts *Options, attempts *uint64, expected float64) {
+	interval := opts.ProgressInterval
+
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			p := Progress{
+				Attempts: atomic.LoadUint64(attempts),
+				Elapsed:  now.Sub(start),
+				Expected: expected,
+			}
+
+			p.Rate = float64(p.Attempts) / p.Elapsed.Seconds()
+
+			if p.Rate > 0 {
+				p.Remaining = time.Duration(expected / p.Rate * float64(time.Second))
+			}
+
+			opts.Progress(p)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package vanity
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/turtlecoin/go-turtlecoin/config"
+)
+
+// TestProgressStops checks Progress is never called once Search has
+// returned, whether it found an address or ctx was cancelled
+func TestProgressStops(t *testing.T) {
+	// takes a few hundred keys on average
+	prefix := FixedPrefix(config.Mainnet) + "v11"
+
+	for i := 0; i < 20; i++ {
+		var returned, late int32
+
+		opts := Options{
+			Workers:          2,
+			ProgressInterval: time.Microsecond,
+			Progress: func(Progress) {
+				if atomic.LoadInt32(&returned) != 0 {
+					atomic.StoreInt32(&late, 1)
+				}
+
+				time.Sleep(time.Millisecond)
+			},
+		}
+
+		result, err := Search(context.Background(), prefix, opts)
+		atomic.StoreInt32(&returned, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(result.Address.String(), prefix) {
+			t.Fatalf("address %s does not start with %s", result.Address, prefix)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+
+		atomic.StoreInt32(&returned, 0)
+
+		// a prefix too unlikely to be found before ctx times out
+		if _, err := Search(ctx, FixedPrefix(config.Mainnet)+"v1111111", opts); err != context.DeadlineExceeded {
+			t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		atomic.StoreInt32(&returned, 1)
+		cancel()
+
+		time.Sleep(5 * time.Millisecond)
+
+		if atomic.LoadInt32(&late) != 0 {
+			t.Fatal("Progress was called after Search returned")
+		}
+	}
+}
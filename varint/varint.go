@@ -0,0 +1,149 @@
+/*
+
+Copyright 2012-2013 The CryptoNote Developers
+Copyright 2014-2018 The Monero Developers
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+// Package varint implements the variable length integers CryptoNote uses
+// in transactions, blocks and addresses. A number is written 7 bits at a
+// time, lowest first, with the top bit of each byte set when more bytes
+// follow. Unlike encoding/binary, decoding rejects numbers written with
+// more bytes than needed, so every number has exactly one encoding.
+package varint
+
+import (
+	"errors"
+	"io"
+)
+
+// MaxLen is the largest number of bytes a 64 bit varint takes
+const MaxLen = 10
+
+// ErrTruncated is returned when the data ends before the last byte of a
+// varint
+var ErrTruncated = errors.New("varint: truncated")
+
+// ErrOverflow is returned when a varint doesn't fit in 64 bits
+var ErrOverflow = errors.New("varint: overflows 64 bits")
+
+// ErrNonCanonical is returned when a varint ends in a zero byte, so it is
+// longer than it needs to be
+var ErrNonCanonical = errors.New("varint: not canonical")
+
+// Len returns the number of bytes in the encoding of n
+func Len(n uint64) int {
+	res := 1
+
+	for n >= 0x80 {
+		n >>= 7
+		res++
+	}
+
+	return res
+}
+
+// Append appends the encoding of n to buf and returns the extended buffer
+func Append(buf []byte, n uint64) []byte {
+	for n >= 0x80 {
+		buf = append(buf, byte(n)|0x80)
+		n >>= 7
+	}
+
+	return append(buf, byte(n))
+}
+
+// Encode returns the encoding of n
+func Encode(n uint64) []byte {
+	return Append(make([]byte, 0, Len(n)), n)
+}
+
+// next adds b, the byte at position i of a varint, to n, returning whether
+// it is the last byte
+func next(n *uint64, b byte, i int) (bool, error) {
+	// the tenth byte only has room for the top bit
+	if i == MaxLen-1 && b > 1 {
+		return false, ErrOverflow
+	}
+
+	*n |= uint64(b&0x7f) << (7 * uint(i))
+
+	if b&0x80 != 0 {
+		return false, nil
+	}
+
+	if b == 0 && i > 0 {
+		return false, ErrNonCanonical
+	}
+
+	return true, nil
+}
+
+// Decode reads a varint from the start of buf, returning it and the number
+// of bytes it takes
+func Decode(buf []byte) (uint64, int, error) {
+	var n uint64
+
+	for i, b := range buf {
+		last, err := next(&n, b, i)
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if last {
+			return n, i + 1, nil
+		}
+	}
+
+	return 0, 0, ErrTruncated
+}
+
+// Read reads a varint from r a byte at a time. If r ends part way through
+// the varint, the error is ErrTruncated, or io.EOF if it ends before the
+// first byte.
+func Read(r io.Reader) (uint64, error) {
+	var n uint64
+	var b [1]byte
+
+	byteReader, _ := r.(io.ByteReader)
+
+	for i := 0; ; i++ {
+		var err error
+
+		if byteReader != nil {
+			b[0], err = byteReader.ReadByte()
+		} else {
+			_, err = io.ReadFull(r, b[:])
+		}
+
+		if err == io.EOF && i > 0 {
+			err = ErrTruncated
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		last, err := next(&n, b[0], i)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if last {
+			return n, nil
+		}
+	}
+}
+
+// Write writes the encoding of n to w, returning the number of bytes
+// written
+func Write(w io.Writer, n uint64) (int, error) {
+	var buf [MaxLen]byte
+
+	return w.Write(Append(buf[:0], n))
+}
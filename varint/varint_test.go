@@ -0,0 +1,184 @@
+/*
+
+Copyright 2018 The TurtleCoin Developers
+
+Please see the included LICENSE file for more information.
+
+*/
+
+package varint
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+var varintTests = []struct {
+	n       uint64
+	encoded string
+}{
+	{0, "00"},
+	{1, "01"},
+	{0x7f, "7f"},
+	{0x80, "8001"},
+	{300, "ac02"},
+	{0x3fff, "ff7f"},
+	{0x4000, "808001"},
+	// the TurtleCoin address prefix
+	{3914525, "9df6ee01"},
+	{math.MaxUint32, "ffffffff0f"},
+	{math.MaxUint64, "ffffffffffffffffff01"},
+}
+
+// errorTests are encodings which don't decode
+var errorTests = []struct {
+	name    string
+	encoded string
+	want    error
+}{
+	{"empty", "", ErrTruncated},
+	{"one byte missing", "80", ErrTruncated},
+	{"nine bytes missing", "ffffffffffffffffff", ErrTruncated},
+	{"tenth byte above 1", "ffffffffffffffffff02", ErrOverflow},
+	{"eleven bytes", "8080808080808080808001", ErrOverflow},
+	{"trailing zero", "8000", ErrNonCanonical},
+	{"trailing zero of a larger number", "ac8000", ErrNonCanonical},
+	{"tenth byte zero", "80808080808080808000", ErrNonCanonical},
+}
+
+// readerOnly hides the ReadByte method of a reader, so Read uses Read
+type readerOnly struct {
+	io.Reader
+}
+
+// errReader returns err after data
+type errReader struct {
+	data []byte
+	err  error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, r.err
+	}
+
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+
+	return n, nil
+}
+
+// errWriter fails every write
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func fromHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	res, err := hex.DecodeString(s)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func TestEncode(t *testing.T) {
+	for _, test := range varintTests {
+		want := fromHex(t, test.encoded)
+
+		if res := Encode(test.n); !bytes.Equal(res, want) {
+			t.Errorf("Encode(%d) = %x, want %s", test.n, res, test.encoded)
+		}
+
+		if n := Len(test.n); n != len(want) {
+			t.Errorf("Len(%d) = %d, want %d", test.n, n, len(want))
+		}
+
+		if res := Append([]byte{0xaa}, test.n); !bytes.Equal(res, append([]byte{0xaa}, want...)) {
+			t.Errorf("Append(aa, %d) = %x, want aa%s", test.n, res, test.encoded)
+		}
+
+		var buf bytes.Buffer
+
+		if n, err := Write(&buf, test.n); err != nil || n != len(want) || !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("Write(%d) = %d, %v, wrote %x, want %s", test.n, n, err, buf.Bytes(), test.encoded)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, test := range varintTests {
+		data := append(fromHex(t, test.encoded), 0x01)
+
+		if n, size, err := Decode(data); err != nil || n != test.n || size != len(data)-1 {
+			t.Errorf("Decode(%x) = %d, %d, %v, want %d, %d", data, n, size, err, test.n, len(data)-1)
+		}
+
+		for _, r := range []io.Reader{bytes.NewReader(data), readerOnly{bytes.NewReader(data)}} {
+			if n, err := Read(r); err != nil || n != test.n {
+				t.Errorf("Read(%x) = %d, %v, want %d", data, n, err, test.n)
+			}
+
+			// the byte after the varint is left to be read
+			if rest, _ := io.ReadAll(r); !bytes.Equal(rest, []byte{0x01}) {
+				t.Errorf("Read(%x) left %x, want 01", data, rest)
+			}
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, test := range errorTests {
+		data := fromHex(t, test.encoded)
+
+		if _, _, err := Decode(data); err != test.want {
+			t.Errorf("%s: Decode(%x) err = %v, want %v", test.name, data, err, test.want)
+		}
+
+		want := test.want
+
+		// a reader which ends before the first byte gives io.EOF
+		if len(data) == 0 {
+			want = io.EOF
+		}
+
+		for _, r := range []io.Reader{bytes.NewReader(data), readerOnly{bytes.NewReader(data)}} {
+			if _, err := Read(r); err != want {
+				t.Errorf("%s: Read(%x) err = %v, want %v", test.name, data, err, want)
+			}
+		}
+	}
+}
+
+func TestReadWriteErrors(t *testing.T) {
+	errRead := errors.New("read failed")
+
+	// errors other than io.EOF are returned as they are, before and part
+	// way through a varint
+	for _, data := range [][]byte{nil, {0x80}} {
+		if _, err := Read(&errReader{data: data, err: errRead}); err != errRead {
+			t.Errorf("reader failing after %x: err = %v, want %v", data, err, errRead)
+		}
+	}
+
+	if n, err := Read(&errReader{data: []byte{0xac, 0x02}, err: errRead}); err != nil || n != 300 {
+		t.Errorf("reader failing after the varint: Read = %d, %v, want 300", n, err)
+	}
+
+	errWrite := errors.New("write failed")
+
+	if _, err := Write(errWriter{errWrite}, 300); err != errWrite {
+		t.Errorf("Write: err = %v, want %v", err, errWrite)
+	}
+}